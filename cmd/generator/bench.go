@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mokiat/lacking/debug/log"
+	"github.com/mokiat/rally-mka/internal/game/level"
+	"github.com/urfave/cli/v2"
+)
+
+func benchCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "bench",
+		Usage: "Measures how long board generation takes",
+		Description: "Generates boards of each layout with consecutive seeds and reports duration and backtrack " +
+			"percentiles, counting boards that exceed the timeout separately from boards that fail for other " +
+			"reasons. The default layouts finish well within the default timeout. The report can be saved as JSON and " +
+			"compared against a saved baseline, in which case the command exits with code 3 if any layout got slower " +
+			"or needed more backtracks than the tolerance allows.",
+		Flags: []cli.Flag{
+			&cli.IntSliceFlag{Name: "sizes", Value: cli.NewIntSlice(5, 7, 9, 11), Usage: "sizes of the square boards to generate"},
+			&cli.IntSliceFlag{Name: "radii", Value: cli.NewIntSlice(4), Usage: "radii of the hexagon boards to generate"},
+			&cli.IntFlag{Name: "count", Value: 20, Usage: "number of boards to generate for each layout"},
+			&cli.Uint64Flag{Name: "seed", Value: 1, Usage: "seed of the first board of each layout"},
+			&cli.StringFlag{Name: "strategy", Value: "backtrack", Usage: "generation strategy to use (backtrack or wfc)"},
+			&cli.DurationFlag{Name: "timeout", Value: 5 * time.Second, Usage: "maximum time to spend on a single board"},
+			&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "file to write the JSON report to"},
+			&cli.StringFlag{Name: "baseline", Usage: "JSON report to compare the results against"},
+			&cli.Float64Flag{Name: "tolerance", Value: 0.2, Usage: "fraction by which a result can exceed the baseline"},
+		},
+		Action: runBench,
+	}
+}
+
+type benchReport struct {
+	Strategy string              `json:"strategy"`
+	Seed     uint64              `json:"seed"`
+	Count    int                 `json:"count"`
+	Layouts  []benchLayoutReport `json:"layouts"`
+}
+
+type benchLayoutReport struct {
+	Layout     string           `json:"layout"`
+	Failures   int              `json:"failures"`
+	Timeouts   int              `json:"timeouts"`
+	Duration   benchPercentiles `json:"duration"`
+	Backtracks benchPercentiles `json:"backtracks"`
+}
+
+// benchPercentiles holds the distribution of a measurement, with durations
+// in nanoseconds.
+type benchPercentiles struct {
+	P50 int64 `json:"p50"`
+	P90 int64 `json:"p90"`
+	P99 int64 `json:"p99"`
+	Max int64 `json:"max"`
+}
+
+func runBench(ctx *cli.Context) error {
+	strategy := ctx.String("strategy")
+	newGenerator, err := generatorStrategy(strategy)
+	if err != nil {
+		return err
+	}
+	layouts, err := benchLayouts(ctx)
+	if err != nil {
+		return err
+	}
+	count := ctx.Int("count")
+	if count <= 0 {
+		return usageError("count needs to be positive")
+	}
+	if ctx.Float64("tolerance") < 0 {
+		return usageError("tolerance cannot be negative")
+	}
+	var baseline *benchReport
+	if path := ctx.String("baseline"); path != "" {
+		if baseline, err = readBenchReport(path); err != nil {
+			return err
+		}
+	}
+
+	signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report := benchReport{
+		Strategy: strategy,
+		Seed:     ctx.Uint64("seed"),
+		Count:    count,
+	}
+	for _, layout := range layouts {
+		layoutReport := benchLayoutReport{
+			Layout: layoutName(layout),
+		}
+		var durations, backtracks []int64
+		for i := range count {
+			generator := newGenerator(level.GeneratorConfig{
+				Seed:       report.Seed + uint64(i),
+				Layout:     layout,
+				TimeBudget: ctx.Duration("timeout"),
+			})
+			_, err := generator.Generate(signalCtx)
+			if signalCtx.Err() != nil {
+				return signalCtx.Err()
+			}
+			stats := generator.Stats()
+			durations = append(durations, int64(stats.Duration))
+			backtracks = append(backtracks, int64(stats.Backtracks))
+			switch {
+			case errors.Is(err, context.DeadlineExceeded):
+				layoutReport.Timeouts++
+			case err != nil:
+				layoutReport.Failures++
+			}
+		}
+		layoutReport.Duration = percentiles(durations)
+		layoutReport.Backtracks = percentiles(backtracks)
+		log.Info("Benchmarked layout %s (p50: %s, max: %s, failures: %d, timeouts: %d)", layoutReport.Layout,
+			time.Duration(layoutReport.Duration.P50), time.Duration(layoutReport.Duration.Max), layoutReport.Failures, layoutReport.Timeouts)
+		report.Layouts = append(report.Layouts, layoutReport)
+	}
+
+	if err := printBenchReport(report); err != nil {
+		return err
+	}
+	if path := ctx.String("output"); path != "" {
+		if err := writeBenchReport(path, report); err != nil {
+			return err
+		}
+	}
+	if baseline != nil {
+		if regressions := compareBenchReports(*baseline, report, ctx.Float64("tolerance")); regressions > 0 {
+			return cli.Exit(fmt.Sprintf("found %d regressions against the baseline", regressions), exitCodeInvalid)
+		}
+		log.Info("No regressions against the baseline")
+	}
+	return nil
+}
+
+// benchLayouts returns the square layouts followed by the hexagon layouts
+// that the flags ask for.
+func benchLayouts(ctx *cli.Context) ([]level.Layout, error) {
+	var layouts []level.Layout
+	for _, size := range ctx.IntSlice("sizes") {
+		if size < 3 {
+			return nil, usageError("boards need to be at least 3 tiles wide and high")
+		}
+		layouts = append(layouts, level.SquareLayout(size))
+	}
+	for _, radius := range ctx.IntSlice("radii") {
+		if radius < 1 {
+			return nil, usageError("hexagon boards need a radius of at least 1")
+		}
+		layouts = append(layouts, level.HexagonLayout(radius))
+	}
+	return layouts, nil
+}
+
+func layoutName(layout level.Layout) string {
+	if layout.Kind == level.LayoutKindHexagon {
+		return fmt.Sprintf("hexagon-%d", layout.Radius)
+	}
+	return fmt.Sprintf("%dx%d", layout.Width, layout.Height)
+}
+
+// percentiles returns the nearest-rank percentiles of the values.
+func percentiles(values []int64) benchPercentiles {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	rank := func(percentile int) int64 {
+		index := (percentile*len(sorted)+99)/100 - 1
+		return sorted[max(0, index)]
+	}
+	return benchPercentiles{
+		P50: rank(50),
+		P90: rank(90),
+		P99: rank(99),
+		Max: sorted[len(sorted)-1],
+	}
+}
+
+// compareBenchReports logs each layout whose duration or backtrack
+// percentiles exceed those of the baseline by more than the tolerance and
+// returns the number of such regressions.
+func compareBenchReports(baseline, current benchReport, tolerance float64) int {
+	if baseline.Strategy != current.Strategy || baseline.Seed != current.Seed || baseline.Count != current.Count {
+		log.Warn("Baseline was measured with different settings, results may not be comparable")
+	}
+	var regressions int
+	for _, currentLayout := range current.Layouts {
+		index := slices.IndexFunc(baseline.Layouts, func(layout benchLayoutReport) bool {
+			return layout.Layout == currentLayout.Layout
+		})
+		if index < 0 {
+			log.Warn("Baseline has no results for layout %s", currentLayout.Layout)
+			continue
+		}
+		baselineLayout := baseline.Layouts[index]
+		check := func(name string, baselineValue, currentValue int64) {
+			if float64(currentValue) > float64(baselineValue)*(1.0+tolerance) {
+				log.Warn("Layout %s regressed in %s: %d -> %d", currentLayout.Layout, name, baselineValue, currentValue)
+				regressions++
+			}
+		}
+		check("p50 duration", baselineLayout.Duration.P50, currentLayout.Duration.P50)
+		check("p90 duration", baselineLayout.Duration.P90, currentLayout.Duration.P90)
+		check("p90 backtracks", baselineLayout.Backtracks.P90, currentLayout.Backtracks.P90)
+		check("failures", int64(baselineLayout.Failures), int64(currentLayout.Failures))
+		check("timeouts", int64(baselineLayout.Timeouts), int64(currentLayout.Timeouts))
+	}
+	return regressions
+}
+
+func printBenchReport(report benchReport) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "LAYOUT\tFAILURES\tTIMEOUTS\tP50\tP90\tP99\tMAX\tBACKTRACKS P50\tP90\tP99\tMAX")
+	for _, layout := range report.Layouts {
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\n",
+			layout.Layout, layout.Failures, layout.Timeouts,
+			time.Duration(layout.Duration.P50), time.Duration(layout.Duration.P90),
+			time.Duration(layout.Duration.P99), time.Duration(layout.Duration.Max),
+			layout.Backtracks.P50, layout.Backtracks.P90, layout.Backtracks.P99, layout.Backtracks.Max,
+		)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("error writing report: %w", err)
+	}
+	return nil
+}
+
+func readBenchReport(path string) (*benchReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading baseline: %w", err)
+	}
+	var report benchReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("error decoding baseline: %w", err)
+	}
+	return &report, nil
+}
+
+func writeBenchReport(path string, report benchReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("error writing report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+	"github.com/mokiat/rally-mka/internal/game/preview"
+)
+
+const (
+	formatJSON   = "json"
+	formatBinary = "binary"
+	formatText   = "text"
+	formatPNG    = "png"
+	formatSVG    = "svg"
+)
+
+// boardFormats are the formats that boards can be read from and written to.
+var boardFormats = []string{formatJSON, formatBinary, formatText}
+
+// imageFormats are the formats that boards can only be written to.
+var imageFormats = []string{formatPNG, formatSVG}
+
+// formatExtension returns the file extension that is used for the format.
+func formatExtension(format string) string {
+	switch format {
+	case formatBinary:
+		return ".bin"
+	case formatText:
+		return ".txt"
+	default:
+		return "." + format
+	}
+}
+
+// readBoardFile reads a board from a file in any of the board formats,
+// which is detected from the content of the file.
+func readBoardFile(path string) (*level.Board, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading board file: %w", err)
+	}
+	board, err := decodeBoard(data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", path, err)
+	}
+	return board, nil
+}
+
+func decodeBoard(data []byte) (*level.Board, error) {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		return level.ParseBoard(trimmed)
+	case bytes.HasPrefix(trimmed, []byte("layout")):
+		return level.ParseBoardText(string(trimmed))
+	case bytes.HasPrefix(data, []byte("MKAB")):
+		return level.DecodeBoard(data)
+	default:
+		// Binary boards are often passed around as base64 strings.
+		return level.DecodeBoardString(string(trimmed))
+	}
+}
+
+// writeBoard writes the board in any of the board or image formats.
+func writeBoard(out io.Writer, board *level.Board, format string, options preview.Options) error {
+	var data []byte
+	var err error
+	switch format {
+	case formatJSON:
+		data, err = level.SerializeBoard(board)
+		data = append(data, '\n')
+	case formatBinary:
+		data, err = level.EncodeBoard(board)
+	case formatText:
+		var text string
+		text, err = level.FormatBoardText(board)
+		data = []byte(text)
+	case formatPNG:
+		return preview.WritePNG(out, board, options)
+	case formatSVG:
+		return preview.WriteSVG(out, board, options)
+	default:
+		return usageError("unknown format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("error encoding board: %w", err)
+	}
+	if _, err := out.Write(data); err != nil {
+		return fmt.Errorf("error writing board: %w", err)
+	}
+	return nil
+}
+
+// printBoard writes the board to the standard output. Binary boards are
+// written as base64 strings, so that they can be copied.
+func printBoard(board *level.Board, format string) error {
+	switch format {
+	case formatBinary:
+		text, err := level.EncodeBoardString(board)
+		if err != nil {
+			return fmt.Errorf("error encoding board: %w", err)
+		}
+		fmt.Println(text)
+		return nil
+	case formatPNG, formatSVG:
+		return usageError("format %q requires an output directory", format)
+	default:
+		return writeBoard(os.Stdout, board, format, preview.Options{})
+	}
+}
+
+// saveBoard writes the board to a file with the specified name in the
+// directory, adding the extension of the format.
+func saveBoard(dir, name string, board *level.Board, format string, options preview.Options) (string, error) {
+	path := filepath.Join(dir, name+formatExtension(format))
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("error creating board file: %w", err)
+	}
+	defer file.Close()
+	if err := writeBoard(file, board, format, options); err != nil {
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("error closing board file: %w", err)
+	}
+	return path, nil
+}
+
+// boardName returns the name of a board file without its directory and
+// extension.
+func boardName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+func checkFormat(format string, allowed ...[]string) error {
+	var names []string
+	for _, formats := range allowed {
+		for _, name := range formats {
+			if name == format {
+				return nil
+			}
+			names = append(names, name)
+		}
+	}
+	return usageError("unknown format %q, expected one of %s", format, strings.Join(names, ", "))
+}
+
+func generatorStrategy(name string) (func(level.GeneratorConfig) level.BoardGenerator, error) {
+	switch name {
+	case "backtrack":
+		return func(config level.GeneratorConfig) level.BoardGenerator {
+			return level.NewGenerator(config)
+		}, nil
+	case "wfc":
+		return func(config level.GeneratorConfig) level.BoardGenerator {
+			return level.NewWFCGenerator(config)
+		}, nil
+	default:
+		return nil, usageError("unknown strategy %q", name)
+	}
+}
+
+func roadNetworkKind(name string) (level.RoadNetworkKind, error) {
+	switch name {
+	case "any":
+		return level.RoadNetworkKindAny, nil
+	case "connected":
+		return level.RoadNetworkKindConnected, nil
+	case "pruned":
+		return level.RoadNetworkKindPruned, nil
+	default:
+		return 0, usageError("unknown road network %q", name)
+	}
+}
+
+func biomeKind(name string) (level.BiomeKind, error) {
+	switch name {
+	case "meadow":
+		return level.BiomeKindMeadow, nil
+	default:
+		return 0, usageError("unknown biome %q", name)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mokiat/rally-mka/internal/game/preview"
+	"github.com/urfave/cli/v2"
+)
+
+func convertCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "convert",
+		Usage:       "Converts a board file to another format",
+		Description: "Reads a board in any format and writes it in the requested one, to a file or to the standard output.",
+		ArgsUsage:   "<board file>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "to", Required: true, Usage: "format to convert to (json, binary or text)"},
+			&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "file to write to instead of the standard output"},
+		},
+		Action: runConvert,
+	}
+}
+
+func runConvert(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return usageError("expected exactly one board file")
+	}
+	format := ctx.String("to")
+	if err := checkFormat(format, boardFormats); err != nil {
+		return err
+	}
+	board, err := readBoardFile(ctx.Args().First())
+	if err != nil {
+		return cli.Exit(err.Error(), exitCodeInvalid)
+	}
+	outputPath := ctx.String("output")
+	if outputPath == "" {
+		return printBoard(board, format)
+	}
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	defer file.Close()
+	if err := writeBoard(file, board, format, preview.Options{}); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error closing output file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mokiat/lacking/debug/log"
+	"github.com/mokiat/rally-mka/internal/game/level"
+	"github.com/mokiat/rally-mka/internal/game/preview"
+	"github.com/urfave/cli/v2"
+)
+
+func generateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "generate",
+		Usage: "Generates boards",
+		Description: "Generates boards and writes each of them to the output directory, or prints them " +
+			"when no directory is specified. Boards that have the same track as an earlier one are skipped. " +
+			"Boards are named after their level codes, unless an option that a level code cannot reproduce " +
+			"is used, in which case they are named after their seeds. A level code already determines the " +
+			"layout, biome and seed of its single board, so it cannot be combined with the options for these. " +
+			"Exits with code 3 if not all boards could be generated, where skipped duplicates do not count as failures.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "code", Usage: "level code of a board to reproduce"},
+			&cli.IntFlag{Name: "size", Value: 9, Usage: "size of square boards"},
+			&cli.IntFlag{Name: "width", Usage: "width of rectangular boards, overrides size"},
+			&cli.IntFlag{Name: "height", Usage: "height of rectangular boards, overrides size"},
+			&cli.IntFlag{Name: "radius", Usage: "radius of hexagon boards, overrides size"},
+			&cli.Uint64Flag{Name: "seed", Usage: "seed of the first board, with the following boards using the next seeds (random when not set)"},
+			&cli.IntFlag{Name: "count", Value: 1, Usage: "number of boards to generate"},
+			&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "directory to write the boards to"},
+			&cli.StringFlag{Name: "format", Value: formatJSON, Usage: "format of the boards (json, binary, text, png or svg)"},
+			&cli.StringFlag{Name: "strategy", Value: "backtrack", Usage: "generation strategy to use (backtrack or wfc)"},
+			&cli.DurationFlag{Name: "timeout", Usage: "maximum time to spend on a single board"},
+			&cli.BoolFlag{Name: "circuit", Usage: "require a closed circuit through the start tile"},
+			&cli.IntFlag{Name: "min-lap", Usage: "minimum number of tiles in the required circuit"},
+			&cli.StringFlag{Name: "network", Value: "any", Usage: "handling of roads not connected to the start tile (any, connected or pruned)"},
+			&cli.StringFlag{Name: "biome", Value: "meadow", Usage: "biome of the generated boards (only meadow for now)"},
+			&cli.IntFlag{Name: "elevation", Usage: "maximum elevation of hills along the roads"},
+			&cli.Float64Flag{Name: "min-difficulty", Usage: "minimum difficulty of the generated tracks (0 to 10)"},
+			&cli.Float64Flag{Name: "max-difficulty", Usage: "maximum difficulty of the generated tracks (0 to 10, 0 for no limit)"},
+		},
+		Action: runGenerate,
+	}
+}
+
+func runGenerate(ctx *cli.Context) error {
+	format := ctx.String("format")
+	if err := checkFormat(format, boardFormats, imageFormats); err != nil {
+		return err
+	}
+	outputDir := ctx.String("output")
+	if outputDir != "" {
+		if err := os.MkdirAll(outputDir, 0o755); err != nil {
+			return fmt.Errorf("error creating output directory: %w", err)
+		}
+	}
+	newGenerator, err := generatorStrategy(ctx.String("strategy"))
+	if err != nil {
+		return err
+	}
+	roadNetwork, err := roadNetworkKind(ctx.String("network"))
+	if err != nil {
+		return err
+	}
+	biome, err := biomeKind(ctx.String("biome"))
+	if err != nil {
+		return err
+	}
+	layout, err := generateLayout(ctx)
+	if err != nil {
+		return err
+	}
+
+	signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	emit := func(name string, board *level.Board) error {
+		if outputDir == "" {
+			return printBoard(board, format)
+		}
+		path, err := saveBoard(outputDir, name, board, format, preview.Options{})
+		if err != nil {
+			return err
+		}
+		log.Info("Wrote board %s", path)
+		return nil
+	}
+
+	// Level codes only hold the layout, biome and seed, so boards that
+	// depend on any other option cannot be reproduced from a code.
+	optionFlags := nonDefaultGeneratorFlags(ctx)
+
+	if ctx.IsSet("code") {
+		conflictingFlags := optionFlags
+		for _, name := range []string{"size", "width", "height", "radius", "seed", "count", "biome"} {
+			if ctx.IsSet(name) {
+				conflictingFlags = append(conflictingFlags, name)
+			}
+		}
+		if len(conflictingFlags) > 0 {
+			return usageError("code cannot be combined with --%s", strings.Join(conflictingFlags, ", --"))
+		}
+		code, err := level.ParseLevelCode(ctx.String("code"))
+		if err != nil {
+			return usageError("%v", err)
+		}
+		board, err := code.Generate(signalCtx)
+		if err != nil {
+			return cli.Exit(err.Error(), exitCodeInvalid)
+		}
+		return emit(code.String(), board)
+	}
+	useCodes := len(optionFlags) == 0
+	if !useCodes {
+		log.Info("Boards are named after their seeds, since level codes cannot reproduce --%s", strings.Join(optionFlags, ", --"))
+	} else if _, err := level.NewLevelCode(layout, 0); err != nil {
+		log.Info("Boards are named after their seeds: %v", err)
+		useCodes = false
+	}
+
+	nextSeed := func() uint64 {
+		return rand.Uint64()
+	}
+	if ctx.IsSet("seed") {
+		seed := ctx.Uint64("seed")
+		nextSeed = func() uint64 {
+			seed++
+			return seed - 1
+		}
+	}
+
+	var (
+		count         = ctx.Int("count")
+		worstDuration time.Duration
+		totalDuration time.Duration
+		boardCount    int
+		skippedCount  int
+		hashes        = make(map[string]string)
+	)
+	for range count {
+		seed := nextSeed()
+		name := fmt.Sprintf("seed-%d", seed)
+		if useCodes {
+			code, err := level.NewLevelCode(layout, seed)
+			if err != nil {
+				return err
+			}
+			code.Biome = biome
+			name = code.String()
+		}
+		generator := newGenerator(level.GeneratorConfig{
+			Seed:           seed,
+			Layout:         layout,
+			TimeBudget:     ctx.Duration("timeout"),
+			RequireCircuit: ctx.Bool("circuit"),
+			MinLapLength:   ctx.Int("min-lap"),
+			RoadNetwork:    roadNetwork,
+			Biome:          biome,
+			MaxElevation:   ctx.Int("elevation"),
+			MinDifficulty:  ctx.Float64("min-difficulty"),
+			MaxDifficulty:  ctx.Float64("max-difficulty"),
+		})
+		board, err := generator.Generate(signalCtx)
+		stats := generator.Stats()
+		if err != nil {
+			if signalCtx.Err() != nil {
+				return err
+			}
+			if errors.Is(err, level.ErrUnsatisfiableConstraints) {
+				// Other seeds would fail in the same way.
+				return usageError("%v", err)
+			}
+			log.Warn("Skipping board %s: %v (backtracks: %d, deepest index: %d)", name, err, stats.Backtracks, stats.DeepestIndex)
+			continue
+		}
+		hash := board.CanonicalHash()
+		if original, ok := hashes[hash]; ok {
+			log.Info("Skipping board %s: same track as %s", name, original)
+			skippedCount++
+			continue
+		}
+		hashes[hash] = name
+		totalDuration += stats.Duration
+		worstDuration = max(worstDuration, stats.Duration)
+		boardCount++
+		log.Info("Generated board %s in %s (attempts: %d, backtracks: %d, retries: %d)", name, stats.Duration, stats.Attempts, stats.Backtracks, stats.Retries)
+		if err := emit(name, board); err != nil {
+			return err
+		}
+	}
+
+	if boardCount > 0 {
+		log.Info("Generated %d boards (average: %s, worst: %s)", boardCount, totalDuration/time.Duration(boardCount), worstDuration)
+	}
+	if skippedCount > 0 {
+		log.Info("Skipped %d boards with the same track as an earlier one", skippedCount)
+	}
+	if boardCount+skippedCount < count {
+		return cli.Exit(fmt.Sprintf("generated %d of %d boards", boardCount, count-skippedCount), exitCodeInvalid)
+	}
+	return nil
+}
+
+// nonDefaultGeneratorFlags returns the flags that change the generated
+// boards in a way that a level code cannot capture.
+func nonDefaultGeneratorFlags(ctx *cli.Context) []string {
+	var result []string
+	if ctx.String("strategy") != "backtrack" {
+		result = append(result, "strategy")
+	}
+	if ctx.Bool("circuit") {
+		result = append(result, "circuit")
+	}
+	if ctx.Int("min-lap") != 0 {
+		result = append(result, "min-lap")
+	}
+	if ctx.String("network") != "any" {
+		result = append(result, "network")
+	}
+	if ctx.Int("elevation") != 0 {
+		result = append(result, "elevation")
+	}
+	if ctx.Float64("min-difficulty") != 0 {
+		result = append(result, "min-difficulty")
+	}
+	if ctx.Float64("max-difficulty") != 0 {
+		result = append(result, "max-difficulty")
+	}
+	return result
+}
+
+func generateLayout(ctx *cli.Context) (level.Layout, error) {
+	var layout level.Layout
+	switch {
+	case ctx.IsSet("radius"):
+		layout = level.HexagonLayout(ctx.Int("radius"))
+	case ctx.IsSet("width") || ctx.IsSet("height"):
+		layout = level.RectangleLayout(ctx.Int("width"), ctx.Int("height"))
+	default:
+		layout = level.SquareLayout(ctx.Int("size"))
+	}
+	if layout.Width < 3 || layout.Height < 3 {
+		return level.Layout{}, usageError("boards need to be at least 3 tiles wide and high")
+	}
+	return layout, nil
+}
@@ -1,48 +1,60 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"math/rand/v2"
 	"os"
-	"time"
 
 	"github.com/mokiat/lacking/debug/log"
-	"github.com/mokiat/rally-mka/internal/game/level"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	exitCodeFailure = 1
+	exitCodeUsage   = 2
+	exitCodeInvalid = 3
 )
 
 func main() {
-	if err := runApp(); err != nil {
+	app := &cli.App{
+		Name:        "generator",
+		Usage:       "produce and inspect rally boards",
+		Description: "Generates, validates, measures, converts and renders the boards of the game and benchmarks the generator.",
+		Commands: []*cli.Command{
+			generateCommand(),
+			validateCommand(),
+			statsCommand(),
+			convertCommand(),
+			renderCommand(),
+			benchCommand(),
+		},
+		OnUsageError: onUsageError,
+		// Errors are reported below, so that all of them go through the
+		// same log and exit code handling.
+		ExitErrHandler: func(*cli.Context, error) {},
+	}
+	for _, command := range app.Commands {
+		command.OnUsageError = onUsageError
+	}
+	if err := app.Run(os.Args); err != nil {
 		log.Error("Error: %v", err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
-func runApp() error {
-	random := rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), uint64(time.Now().UnixNano())))
-
-	generator := level.NewGenerator(level.GeneratorConfig{
-		Random: random,
-		Size:   9,
-	})
-
-	worstDuration := time.Duration(0)
-	var board *level.Board
-	for range 100 {
-		startTime := time.Now()
-		board = generator.Generate()
-		elapsedTime := time.Since(startTime)
-		if elapsedTime > worstDuration {
-			worstDuration = elapsedTime
-			log.Info("Generated board in %s", elapsedTime)
-			data, err := level.SerializeBoard(board)
-			if err != nil {
-				return err
-			}
-			fmt.Println()
-			fmt.Println(string(data))
-			fmt.Println()
-		}
+func exitCode(err error) int {
+	var exitCoder cli.ExitCoder
+	if errors.As(err, &exitCoder) {
+		return exitCoder.ExitCode()
 	}
+	return exitCodeFailure
+}
+
+func onUsageError(ctx *cli.Context, err error, isSubcommand bool) error {
+	return cli.Exit(err.Error(), exitCodeUsage)
+}
 
-	return nil
+// usageError reports a problem with the command line arguments.
+func usageError(format string, args ...any) error {
+	return cli.Exit(fmt.Sprintf(format, args...), exitCodeUsage)
 }
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mokiat/lacking/debug/log"
+	"github.com/mokiat/rally-mka/internal/game/data"
+	"github.com/mokiat/rally-mka/internal/game/preview"
+	"github.com/urfave/cli/v2"
+)
+
+func renderCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "render",
+		Usage:       "Draws images of boards",
+		Description: "Draws a top-down image of each board into the output directory, named after the board file.",
+		ArgsUsage:   "[board files...]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Value: ".", Usage: "directory to write the images to"},
+			&cli.StringFlag{Name: "format", Value: formatPNG, Usage: "format of the images (png or svg)"},
+			&cli.Float64Flag{Name: "tile-radius", Usage: "distance in pixels between the center and the corners of a tile"},
+			&cli.BoolFlag{Name: "builtin", Usage: "also draw the built-in levels"},
+		},
+		Action: runRender,
+	}
+}
+
+func runRender(ctx *cli.Context) error {
+	if ctx.NArg() == 0 && !ctx.Bool("builtin") {
+		return usageError("no board files specified")
+	}
+	format := ctx.String("format")
+	if err := checkFormat(format, imageFormats); err != nil {
+		return err
+	}
+	outputDir := ctx.String("output")
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("error creating output directory: %w", err)
+	}
+	options := preview.Options{
+		TileRadius: ctx.Float64("tile-radius"),
+	}
+
+	for _, path := range ctx.Args().Slice() {
+		board, err := readBoardFile(path)
+		if err != nil {
+			return cli.Exit(err.Error(), exitCodeInvalid)
+		}
+		imagePath, err := saveBoard(outputDir, boardName(path), board, format, options)
+		if err != nil {
+			return err
+		}
+		log.Info("Wrote image %s", imagePath)
+	}
+	if ctx.Bool("builtin") {
+		for _, lvl := range data.Levels {
+			imagePath, err := saveBoard(outputDir, lvl.Name, lvl.Board, format, options)
+			if err != nil {
+				return err
+			}
+			log.Info("Wrote image %s", imagePath)
+		}
+	}
+	return nil
+}
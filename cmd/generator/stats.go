@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mokiat/rally-mka/internal/game/data"
+	"github.com/mokiat/rally-mka/internal/game/level"
+	"github.com/urfave/cli/v2"
+)
+
+func statsCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "stats",
+		Usage:       "Measures the tracks of boards",
+		Description: "Prints the track metrics and difficulty of each board, as a table or as JSON.",
+		ArgsUsage:   "[board files...]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "builtin", Usage: "also measure the built-in levels"},
+			&cli.BoolFlag{Name: "json", Usage: "print the metrics as JSON"},
+		},
+		Action: runStats,
+	}
+}
+
+type boardStats struct {
+	Name            string  `json:"name"`
+	Hash            string  `json:"hash"`
+	LapLength       float64 `json:"lapLength"`
+	RoadLength      float64 `json:"roadLength"`
+	LongestStraight float64 `json:"longestStraight"`
+	SmoothCorners   int     `json:"smoothCorners"`
+	SharpCorners    int     `json:"sharpCorners"`
+	Splits          int     `json:"splits"`
+	Intersections   int     `json:"intersections"`
+	DeadEnds        int     `json:"deadEnds"`
+	Slopes          int     `json:"slopes"`
+	Difficulty      float64 `json:"difficulty"`
+}
+
+func runStats(ctx *cli.Context) error {
+	if ctx.NArg() == 0 && !ctx.Bool("builtin") {
+		return usageError("no board files specified")
+	}
+	var result []boardStats
+	add := func(name string, board *level.Board) {
+		metrics := board.Metrics()
+		result = append(result, boardStats{
+			Name:            name,
+			Hash:            board.CanonicalHash(),
+			LapLength:       metrics.LapLength,
+			RoadLength:      metrics.RoadLength,
+			LongestStraight: metrics.LongestStraight,
+			SmoothCorners:   metrics.SmoothCorners,
+			SharpCorners:    metrics.SharpCorners,
+			Splits:          metrics.Splits,
+			Intersections:   metrics.Intersections,
+			DeadEnds:        metrics.DeadEnds,
+			Slopes:          metrics.Slopes,
+			Difficulty:      metrics.Difficulty,
+		})
+	}
+	for _, path := range ctx.Args().Slice() {
+		board, err := readBoardFile(path)
+		if err != nil {
+			return cli.Exit(err.Error(), exitCodeInvalid)
+		}
+		add(boardName(path), board)
+	}
+	if ctx.Bool("builtin") {
+		for _, lvl := range data.Levels {
+			add(lvl.Name, lvl.Board)
+		}
+	}
+
+	if ctx.Bool("json") {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("error writing stats: %w", err)
+		}
+		return nil
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tLAP\tROAD\tSTRAIGHT\tSMOOTH\tSHARP\tSPLITS\tINTERSECTIONS\tDEAD ENDS\tSLOPES\tDIFFICULTY\tHASH")
+	for _, stats := range result {
+		fmt.Fprintf(writer, "%s\t%.0f m\t%.0f m\t%.0f m\t%d\t%d\t%d\t%d\t%d\t%d\t%.1f\t%s\n",
+			stats.Name, stats.LapLength, stats.RoadLength, stats.LongestStraight,
+			stats.SmoothCorners, stats.SharpCorners, stats.Splits, stats.Intersections, stats.DeadEnds, stats.Slopes,
+			stats.Difficulty, stats.Hash[:12],
+		)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("error writing stats: %w", err)
+	}
+	return nil
+}
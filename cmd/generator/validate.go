@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mokiat/lacking/debug/log"
+	"github.com/mokiat/rally-mka/internal/game/data"
+	"github.com/urfave/cli/v2"
+)
+
+func validateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "validate",
+		Usage: "Checks board files",
+		Description: "Checks that each board file can be read and forms a valid board. " +
+			"Exits with code 3 if any board is invalid or if built-in levels share a track.",
+		ArgsUsage: "[board files...]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "builtin", Usage: "also check the built-in levels for duplicate tracks"},
+		},
+		Action: runValidate,
+	}
+}
+
+func runValidate(ctx *cli.Context) error {
+	if ctx.NArg() == 0 && !ctx.Bool("builtin") {
+		return usageError("no board files specified")
+	}
+	var failures int
+	for _, path := range ctx.Args().Slice() {
+		if _, err := readBoardFile(path); err != nil {
+			log.Warn("Invalid board: %v", err)
+			failures++
+			continue
+		}
+		log.Info("Valid board %s", path)
+	}
+	if ctx.Bool("builtin") {
+		duplicates := data.DuplicateLevels(data.Levels)
+		for _, names := range duplicates {
+			log.Warn("Levels have the same track: %s", strings.Join(names, ", "))
+		}
+		failures += len(duplicates)
+		if len(duplicates) == 0 {
+			log.Info("Checked %d built-in levels, no duplicate tracks found", len(data.Levels))
+		}
+	}
+	if failures > 0 {
+		return cli.Exit(fmt.Sprintf("found %d problems", failures), exitCodeInvalid)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ require (
 	github.com/mokiat/lacking-js v0.22.0
 	github.com/mokiat/lacking-native v0.22.0
 	github.com/mokiat/lacking-studio v0.22.0
+	github.com/urfave/cli/v2 v2.27.5
 )
 
 require (
@@ -26,7 +27,6 @@ require (
 	github.com/mokiat/wasmgl v0.7.0 // indirect
 	github.com/qmuntal/gltf v0.28.0 // indirect
 	github.com/russross/blackfriday/v2 v2.1.0 // indirect
-	github.com/urfave/cli/v2 v2.27.5 // indirect
 	github.com/x448/float16 v0.8.4 // indirect
 	github.com/xrash/smetrics v0.0.0-20240521201337-686a1a2994c1 // indirect
 	golang.org/x/image v0.23.0 // indirect
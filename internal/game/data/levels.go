@@ -12,40 +12,132 @@ var Levels []Level
 func init() {
 	Levels = []Level{
 		{
-			Name:  "Journey",
-			Board: mustParseBoard(`{"size":9,"tiles":[{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4}]}`),
+			Name: "Journey",
+			Board: mustParseBoard(`
+				layout square 9
+				biome meadow
+				.4  C1  K0  .1  K1  K1  C1  S0  C0
+				  C2  K3  C0  S4  K4  K4  .2  K1  C5
+				.2  C3  C0  K4  C1  C0  K2  C4  K4
+				  .5  C1  K5  K3  C0  S5  C3  C0  .0
+				K2  C4  .5  C1  S0  K5  C5  .1  C5
+				  S5  K2  C4  .0  K2  C4  .3  C2  .5
+				.3  S2  S2  K2  C0  S5  .2  .1  C5
+				  C1  K5  S2  C3  K5  S5  .0  K3  K0
+				K3  S3  S3  K5  .1  .4  C3  S3  C4
+			`),
 		},
 		{
-			Name:  "Loops Ahead",
-			Board: mustParseBoard(`{"size":9,"tiles":[{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4}]}`),
+			Name: "Loops Ahead",
+			Board: mustParseBoard(`
+				layout square 9
+				biome meadow
+				.5  .0  K1  C1  S0  C0  C1  K0  .5
+				  C1  Y0  K4  K2  K0  K4  K3  C0  .1
+				K3  K0  C5  K1  K4  K1  K1  .5  C5
+				  K3  Y0  K3  K5  C2  K4  S2  C2  .4
+				K2  C0  C5  C1  S0  K5  K2  K5  C5
+				  C5  K4  S1  K1  .2  K1  S2  K3  K0
+				K3  K0  S4  S1  Y1  Y4  Y1  K5  C2
+				  K3  C4  K3  Y0  K1  K4  .3  K1  C5
+				.1  .2  .5  .1  K4  C3  S3  C4  K4
+			`),
 		},
 		{
-			Name:  "Hidden Road",
-			Board: mustParseBoard(`{"size":9,"tiles":[{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4}]}`),
+			Name: "Hidden Road",
+			Board: mustParseBoard(`
+				layout square 9
+				biome meadow
+				K2  S3  C0  C1  K0  .0  .3  .5  .4
+				  C5  K1  K4  C2  C1  K0  C1  C0  .3
+				C2  C2  S5  K1  K4  C2  C2  C1  K5
+				  C3  K5  K4  C5  K2  K5  K4  .1  .2
+				K2  S0  C0  K3  S3  K5  C1  S0  K0
+				  S2  .2  C3  C0  C1  C4  K2  C4  .4
+				.2  S5  .5  .3  K4  C1  K0  C3  C0
+				  .0  S2  C1  S0  C4  S4  C1  K0  C5
+				.4  .1  K4  .3  .0  K3  C4  K3  C4
+			`),
 		},
 		{
-			Name:  "Just Oval",
-			Board: mustParseBoard(`{"size":3,"tiles":[{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2}]}`),
+			Name: "Just Oval",
+			Board: mustParseBoard(`
+				layout square 3
+				biome meadow
+				K2  S3  C0
+				  C3  S3  K5
+				.1  .2  .2
+			`),
 		},
 		{
-			Name:  "The Duck",
-			Board: mustParseBoard(`{"size":5,"tiles":[{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5}]}`),
+			Name: "The Duck",
+			Board: mustParseBoard(`
+				layout square 5
+				biome meadow
+				K2  C0  .3  C1  C0
+				  C3  K5  C2  K2  K5
+				.2  C1  S0  K5  S5
+				  C2  .5  .4  C1  K5
+				.1  C3  S0  C4  .5
+			`),
 		},
 		{
-			Name:  "Bird & Snake",
-			Board: mustParseBoard(`{"size":7,"tiles":[{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4}]}`),
+			Name: "Bird & Snake",
+			Board: mustParseBoard(`
+				layout square 7
+				biome meadow
+				K2  C0  C1  C0  .0  C1  C0
+				  S2  K4  K2  K5  K3  K0  C5
+				K2  K5  C1  K5  .3  K3  Y2
+				  Y1  C4  C1  S0  K0  K1  C5
+				K3  C0  C2  C1  Y0  C2  K4
+				  .1  S5  K4  K2  K5  Y3  K0
+				.2  .4  C3  S3  K5  K3  C4
+			`),
 		},
 		{
-			Name:  "Angry Bot",
-			Board: mustParseBoard(`{"size":7,"tiles":[{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0}]}`),
+			Name: "Angry Bot",
+			Board: mustParseBoard(`
+				layout square 7
+				biome meadow
+				K2  K0  C1  C0  .1  K2  K0
+				  K4  S1  K2  K5  .0  K4  .3
+				K2  C4  .5  C5  K2  S3  C0
+				  S5  .4  K3  S3  K5  .1  C5
+				K2  K5  .2  .5  K1  C1  C4
+				  C5  .4  C1  C4  K4  .1  .0
+				K3  S0  C4  .1  .5  .4  .0
+			`),
 		},
 	}
 }
 
-func mustParseBoard(boardJSON string) *level.Board {
-	board, err := level.ParseBoard([]byte(boardJSON))
+func mustParseBoard(boardText string) *level.Board {
+	board, err := level.ParseBoardText(boardText)
 	if err != nil {
 		panic(err)
 	}
 	return board
 }
+
+// DuplicateLevels returns the names of the levels that have the same track,
+// grouped together, where rotated and mirrored boards count as the same
+// track.
+func DuplicateLevels(levels []Level) [][]string {
+	var hashes []string
+	groups := make(map[string][]string)
+	for _, lvl := range levels {
+		hash := lvl.Board.CanonicalHash()
+		if _, ok := groups[hash]; !ok {
+			hashes = append(hashes, hash)
+		}
+		groups[hash] = append(groups[hash], lvl.Name)
+	}
+	var result [][]string
+	for _, hash := range hashes {
+		if len(groups[hash]) > 1 {
+			result = append(result, groups[hash])
+		}
+	}
+	return result
+}
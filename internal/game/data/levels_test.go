@@ -0,0 +1,78 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/data"
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestLevelsMatchJSONFixtures(t *testing.T) {
+	if len(data.Levels) != len(levelFixtures) {
+		t.Errorf("expected %d levels, got %d", len(levelFixtures), len(data.Levels))
+	}
+	for _, lvl := range data.Levels {
+		fixture, ok := levelFixtures[lvl.Name]
+		if !ok {
+			t.Errorf("%s: no fixture", lvl.Name)
+			continue
+		}
+		expected, err := level.ParseBoard([]byte(fixture))
+		if err != nil {
+			t.Fatalf("%s: %v", lvl.Name, err)
+		}
+		if actual, expectedHash := lvl.Board.CanonicalHash(), expected.CanonicalHash(); actual != expectedHash {
+			t.Errorf("%s: expected hash %s, got %s", lvl.Name, expectedHash, actual)
+		}
+		assertSameBoard(t, lvl.Name, expected, lvl.Board)
+	}
+}
+
+func TestLevelsTextRoundTrip(t *testing.T) {
+	for _, lvl := range data.Levels {
+		text, err := level.FormatBoardText(lvl.Board)
+		if err != nil {
+			t.Fatalf("%s: %v", lvl.Name, err)
+		}
+		board, err := level.ParseBoardText(text)
+		if err != nil {
+			t.Fatalf("%s: %v", lvl.Name, err)
+		}
+		assertSameBoard(t, lvl.Name, lvl.Board, board)
+		formatted, err := level.FormatBoardText(board)
+		if err != nil {
+			t.Fatalf("%s: %v", lvl.Name, err)
+		}
+		if formatted != text {
+			t.Errorf("%s: text changed after round trip:\n%s\nto:\n%s", lvl.Name, text, formatted)
+		}
+	}
+}
+
+func assertSameBoard(t *testing.T, name string, expected, actual *level.Board) {
+	t.Helper()
+	if actual.Layout() != expected.Layout() {
+		t.Errorf("%s: expected layout %+v, got %+v", name, expected.Layout(), actual.Layout())
+		return
+	}
+	if actual.Biome() != expected.Biome() {
+		t.Errorf("%s: expected biome %d, got %d", name, expected.Biome(), actual.Biome())
+	}
+	for _, coord := range expected.Coords() {
+		if actualTile, expectedTile := actual.Tile(coord), expected.Tile(coord); actualTile != expectedTile {
+			t.Errorf("%s: expected tile %+v at %s, got %+v", name, expectedTile, coord, actualTile)
+		}
+	}
+}
+
+// levelFixtures holds the JSON of the built-in levels from before they were
+// written in the text format.
+var levelFixtures = map[string]string{
+	"Journey":      `{"size":9,"tiles":[{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4}]}`,
+	"Loops Ahead":  `{"size":9,"tiles":[{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4}]}`,
+	"Hidden Road":  `{"size":9,"tiles":[{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4}]}`,
+	"Just Oval":    `{"size":3,"tiles":[{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2}]}`,
+	"The Duck":     `{"size":5,"tiles":[{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5}]}`,
+	"Bird & Snake": `{"size":7,"tiles":[{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":5,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4}]}`,
+	"Angry Bot":    `{"size":7,"tiles":[{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":2},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":4,"ground":1,"road":1,"variation":0,"rotation":3},{"shape":2,"ground":1,"road":1,"variation":0,"rotation":0},{"shape":3,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":1},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":5},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":4},{"shape":1,"ground":1,"road":1,"variation":0,"rotation":0}]}`,
+}
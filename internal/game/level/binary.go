@@ -0,0 +1,187 @@
+package level
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+const (
+	binaryMagic   = "MKAB"
+	binaryVersion = 4
+
+	binaryFlagVariations = 1 << 0
+	binaryFlagElevations = 1 << 1
+)
+
+var ErrInvalidBinaryBoard = errors.New("invalid binary board")
+
+// EncodeBoard produces a compact binary representation of the board. Each
+// tile is packed into two bytes and variations and elevations are only
+// stored when at least one tile uses them. The data ends with a CRC32 checksum.
+func EncodeBoard(board *Board) ([]byte, error) {
+	var flags byte
+	for _, tile := range board.tiles {
+		if tile.Shape > 0x0F || tile.Ground > 0x0F || tile.Road > 0x0F || tile.Rotation > 0x0F {
+			return nil, fmt.Errorf("tile %+v cannot be packed", tile)
+		}
+		if tile.Variation != 0 {
+			flags |= binaryFlagVariations
+		}
+		if tile.Elevation != 0 {
+			flags |= binaryFlagElevations
+		}
+	}
+
+	data := make([]byte, 0, len(binaryMagic)+8+3*len(board.tiles))
+	data = append(data, binaryMagic...)
+	data = append(data, binaryVersion, flags)
+	data = append(data, byte(board.layout.Kind))
+	data = binary.AppendUvarint(data, uint64(board.layout.Width))
+	data = binary.AppendUvarint(data, uint64(board.layout.Height))
+	data = binary.AppendUvarint(data, uint64(board.layout.Radius))
+	data = append(data, byte(board.biome))
+	for _, tile := range board.tiles {
+		data = append(data,
+			byte(tile.Shape)|tile.Rotation<<4,
+			byte(tile.Ground)|byte(tile.Road)<<4,
+		)
+	}
+	if flags&binaryFlagVariations != 0 {
+		for _, tile := range board.tiles {
+			data = append(data, tile.Variation)
+		}
+	}
+	if flags&binaryFlagElevations != 0 {
+		for _, tile := range board.tiles {
+			data = append(data, tile.Elevation)
+		}
+	}
+	data = binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(data))
+	return data, nil
+}
+
+func DecodeBoard(data []byte) (*Board, error) {
+	if len(data) < len(binaryMagic)+2+crc32.Size || !bytes.HasPrefix(data, []byte(binaryMagic)) {
+		return nil, ErrInvalidBinaryBoard
+	}
+	payload, checksum := data[:len(data)-crc32.Size], data[len(data)-crc32.Size:]
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(checksum) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidBinaryBoard)
+	}
+	payload = payload[len(binaryMagic):]
+
+	version, flags := payload[0], payload[1]
+	if version > binaryVersion {
+		return nil, fmt.Errorf("%w: version %d is newer than supported version %d", ErrInvalidBinaryBoard, version, binaryVersion)
+	}
+	payload = payload[2:]
+
+	layout, payload, err := decodeBinaryLayout(version, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Versions before 3 did not support biomes and always used meadow.
+	biome := BiomeKindMeadow
+	if version >= 3 {
+		if len(payload) == 0 {
+			return nil, fmt.Errorf("%w: missing biome", ErrInvalidBinaryBoard)
+		}
+		biome, payload = BiomeKind(payload[0]), payload[1:]
+	}
+
+	tileCount := layout.Width * layout.Height
+	expectedLength := 2 * tileCount
+	if flags&binaryFlagVariations != 0 {
+		expectedLength += tileCount
+	}
+	if flags&binaryFlagElevations != 0 {
+		expectedLength += tileCount
+	}
+	if len(payload) != expectedLength {
+		return nil, fmt.Errorf("%w: expected %d bytes of tile data but got %d", ErrInvalidBinaryBoard, expectedLength, len(payload))
+	}
+
+	board := NewBoard(layout)
+	board.SetBiome(biome)
+	for i := range board.tiles {
+		board.tiles[i] = Tile{
+			Shape:    ShapeKind(payload[2*i] & 0x0F),
+			Rotation: payload[2*i] >> 4,
+			Ground:   GroundKind(payload[2*i+1] & 0x0F),
+			Road:     RoadKind(payload[2*i+1] >> 4),
+		}
+	}
+	payload = payload[2*tileCount:]
+	if flags&binaryFlagVariations != 0 {
+		for i := range board.tiles {
+			board.tiles[i].Variation = payload[i]
+		}
+		payload = payload[tileCount:]
+	}
+	if flags&binaryFlagElevations != 0 {
+		for i := range board.tiles {
+			board.tiles[i].Elevation = payload[i]
+		}
+	}
+	if err := validateBoard(board); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+func decodeBinaryLayout(version byte, payload []byte) (Layout, []byte, error) {
+	readNumber := func() (int, bool) {
+		value, n := binary.Uvarint(payload)
+		if n <= 0 || value > 0xFFFF {
+			return 0, false
+		}
+		payload = payload[n:]
+		return int(value), true
+	}
+
+	// Version 1 only supported square boards.
+	if version == 1 {
+		size, ok := readNumber()
+		if !ok {
+			return Layout{}, nil, fmt.Errorf("%w: bad size", ErrInvalidBinaryBoard)
+		}
+		return SquareLayout(size), payload, nil
+	}
+
+	if len(payload) == 0 {
+		return Layout{}, nil, fmt.Errorf("%w: missing layout", ErrInvalidBinaryBoard)
+	}
+	layout := Layout{
+		Kind: LayoutKind(payload[0]),
+	}
+	payload = payload[1:]
+	var widthOK, heightOK, radiusOK bool
+	layout.Width, widthOK = readNumber()
+	layout.Height, heightOK = readNumber()
+	layout.Radius, radiusOK = readNumber()
+	if !widthOK || !heightOK || !radiusOK || !layout.isConsistent() {
+		return Layout{}, nil, fmt.Errorf("%w: bad layout", ErrInvalidBinaryBoard)
+	}
+	return layout, payload, nil
+}
+
+func EncodeBoardString(board *Board) (string, error) {
+	data, err := EncodeBoard(board)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func DecodeBoardString(text string) (*Board, error) {
+	data, err := base64.RawURLEncoding.DecodeString(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBinaryBoard, err)
+	}
+	return DecodeBoard(data)
+}
@@ -0,0 +1,132 @@
+package level_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"slices"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestBinaryBoardRoundTrip(t *testing.T) {
+	varied := ovalBoard(t)
+	tile := varied.Tile(level.C(1, 0))
+	tile.Variation = 3
+	varied.SetTile(level.C(1, 0), tile)
+
+	boards := map[string]*level.Board{
+		"oval":       ovalBoard(t),
+		"variations": varied,
+		"elevations": hillyBoard(t),
+	}
+	for name, board := range boards {
+		text, err := level.EncodeBoardString(board)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		decoded, err := level.DecodeBoardString(text)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		checkSameBoard(t, name, decoded, board)
+	}
+}
+
+func TestDecodeBoardRejectsCorruptData(t *testing.T) {
+	data, err := level.EncodeBoard(ovalBoard(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range data {
+		corrupt := slices.Clone(data)
+		corrupt[i] ^= 0x10
+		if _, err := level.DecodeBoard(corrupt); !errors.Is(err, level.ErrInvalidBinaryBoard) {
+			t.Errorf("byte %d: expected an invalid binary board, got %v", i, err)
+		}
+	}
+	if _, err := level.DecodeBoard(data[:len(data)-1]); !errors.Is(err, level.ErrInvalidBinaryBoard) {
+		t.Errorf("truncated data: expected an invalid binary board, got %v", err)
+	}
+}
+
+func TestDecodeBoardRejectsNewerVersion(t *testing.T) {
+	data, err := level.EncodeBoard(ovalBoard(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := slices.Clone(data[:len(data)-crc32.Size])
+	payload[len("MKAB")] = 0xFF
+	if _, err := level.DecodeBoard(withChecksum(payload)); !errors.Is(err, level.ErrInvalidBinaryBoard) {
+		t.Errorf("expected an invalid binary board, got %v", err)
+	}
+}
+
+func TestDecodeBoardOlderVersions(t *testing.T) {
+	oval := ovalBoard(t)
+	data, err := level.EncodeBoard(oval)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The oval needs neither variations nor elevations, so the current
+	// encoding is the magic, version, flags, layout kind, width, height,
+	// radius and biome, followed by the tiles.
+	header, tiles := data[:len("MKAB")], data[len("MKAB")+7:len(data)-crc32.Size]
+	versions := map[string][]byte{
+		// Version 1 only had square boards and stored their size.
+		"version 1": slices.Concat(header, []byte{1, 0, 3}, tiles),
+		// Version 2 introduced the layout, but had no biome.
+		"version 2": slices.Concat(header, []byte{2, 0, 0, 3, 3, 0}, tiles),
+		// Version 3 introduced the biome, but had no elevations.
+		"version 3": slices.Concat(header, []byte{3, 0, 0, 3, 3, 0, 0}, tiles),
+	}
+	for name, payload := range versions {
+		board, err := level.DecodeBoard(withChecksum(payload))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		checkSameBoard(t, name, board, oval)
+	}
+}
+
+func withChecksum(payload []byte) []byte {
+	return binary.BigEndian.AppendUint32(slices.Clone(payload), crc32.ChecksumIEEE(payload))
+}
+
+// hillyBoard returns a generated board that has raised tiles.
+func hillyBoard(t *testing.T) *level.Board {
+	t.Helper()
+	for seed := range uint64(10) {
+		board, err := level.NewGenerator(level.GeneratorConfig{
+			Seed:         seed,
+			Layout:       level.SquareLayout(9),
+			MaxElevation: 2,
+		}).Generate(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, coord := range board.Coords() {
+			if board.Tile(coord).Elevation > 0 {
+				return board
+			}
+		}
+	}
+	t.Fatal("no generated board has raised tiles")
+	return nil
+}
+
+func checkSameBoard(t *testing.T, name string, actual, expected *level.Board) {
+	t.Helper()
+	if actual.Layout() != expected.Layout() || actual.Biome() != expected.Biome() {
+		t.Errorf("%s: expected layout %+v and biome %d, got %+v and %d", name, expected.Layout(), expected.Biome(), actual.Layout(), actual.Biome())
+		return
+	}
+	for _, coord := range expected.Coords() {
+		if actual.Tile(coord) != expected.Tile(coord) {
+			t.Errorf("%s: expected tile %+v at %s, got %+v", name, expected.Tile(coord), coord, actual.Tile(coord))
+		}
+	}
+}
@@ -4,64 +4,141 @@ import (
 	"encoding/json"
 )
 
-func NewBoard(size int) *Board {
+func NewBoard(layout Layout) *Board {
 	return &Board{
-		size:  size,
-		tiles: make([]Tile, size*size),
+		layout: layout,
+		tiles:  make([]Tile, layout.Width*layout.Height),
 	}
 }
 
 type Board struct {
-	size  int
-	tiles []Tile
+	layout Layout
+	biome  BiomeKind
+	tiles  []Tile
 }
 
-func (b *Board) Size() int {
-	return b.size
+func (b *Board) Layout() Layout {
+	return b.layout
+}
+
+func (b *Board) Biome() BiomeKind {
+	return b.biome
+}
+
+func (b *Board) SetBiome(biome BiomeKind) {
+	b.biome = biome
+}
+
+func (b *Board) Width() int {
+	return b.layout.Width
+}
+
+func (b *Board) Height() int {
+	return b.layout.Height
 }
 
 func (b *Board) Center() Coord {
-	return C(b.size/2, b.size/2)
+	return b.layout.Center()
 }
 
 func (b *Board) ContainsCoord(coord Coord) bool {
-	return coord.X >= 0 && coord.X < b.size && coord.Y >= 0 && coord.Y < b.size
+	return b.layout.ContainsCoord(coord)
+}
+
+// Coords returns all coordinates that are part of the board in row-major
+// order.
+func (b *Board) Coords() []Coord {
+	result := make([]Coord, 0, len(b.tiles))
+	for y := range b.layout.Height {
+		for x := range b.layout.Width {
+			if coord := C(x, y); b.ContainsCoord(coord) {
+				result = append(result, coord)
+			}
+		}
+	}
+	return result
 }
 
 func (b *Board) Tile(coord Coord) Tile {
 	if !b.ContainsCoord(coord) {
 		panic("coord out of bounds")
 	}
-	return b.tiles[coord.X+coord.Y*b.size]
+	return b.tiles[coord.X+coord.Y*b.layout.Width]
 }
 
 func (b *Board) SetTile(coord Coord, tile Tile) {
 	if !b.ContainsCoord(coord) {
 		panic("coord out of bounds")
 	}
-	b.tiles[coord.X+coord.Y*b.size] = tile
+	b.tiles[coord.X+coord.Y*b.layout.Width] = tile
 }
 
 func SerializeBoard(board *Board) ([]byte, error) {
-	return json.Marshal(struct {
-		Size  int    `json:"size"`
-		Tiles []Tile `json:"tiles"`
-	}{
-		Size:  board.size,
-		Tiles: board.tiles,
+	return json.Marshal(boardDocument{
+		Version: BoardFormatVersion,
+		Layout:  board.layout.Kind,
+		Width:   board.layout.Width,
+		Height:  board.layout.Height,
+		Radius:  board.layout.Radius,
+		Biome:   board.biome,
+		Tiles:   board.tiles,
 	})
 }
 
 func ParseBoard(data []byte) (*Board, error) {
-	var parsed struct {
-		Size  int    `json:"size"`
-		Tiles []Tile `json:"tiles"`
+	data, err := migrateBoardDocument(data)
+	if err != nil {
+		return nil, err
 	}
+	var parsed boardDocument
 	if err := json.Unmarshal(data, &parsed); err != nil {
 		return nil, err
 	}
-	return &Board{
-		size:  parsed.Size,
+	board := &Board{
+		layout: Layout{
+			Kind:   parsed.Layout,
+			Width:  parsed.Width,
+			Height: parsed.Height,
+			Radius: parsed.Radius,
+		},
+		biome: parsed.Biome,
 		tiles: parsed.Tiles,
-	}, nil
+	}
+	if err := validateBoard(board); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+type boardDocument struct {
+	Version int        `json:"version"`
+	Layout  LayoutKind `json:"layout"`
+	Width   int        `json:"width"`
+	Height  int        `json:"height"`
+	Radius  int        `json:"radius,omitempty"`
+	Biome   BiomeKind  `json:"biome"`
+	Tiles   []Tile     `json:"tiles"`
+}
+
+func (b *Board) ShapeCounts() map[ShapeKind]int {
+	result := make(map[ShapeKind]int)
+	for _, coord := range b.Coords() {
+		result[b.Tile(coord).Shape]++
+	}
+	return result
+}
+
+// RoadDensity returns the fraction of tiles on the board that have a road.
+func (b *Board) RoadDensity() float64 {
+	coords := b.Coords()
+	if len(coords) == 0 {
+		return 0.0
+	}
+	var roadCount int
+	for _, coord := range coords {
+		if b.Tile(coord).HasAnyRoad() {
+			roadCount++
+		}
+	}
+	return float64(roadCount) / float64(len(coords))
 }
@@ -0,0 +1,114 @@
+package level
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"slices"
+)
+
+// canonicalFormVersion is part of the canonical form, so that hashes from
+// different versions of the form never match.
+const canonicalFormVersion = 1
+
+// CanonicalHash returns a hash of the track on the board that is the same
+// for all boards that differ only by a rotation or a mirroring around their
+// center. Only the biome and the tiles with roads, along with their position
+// relative to the center, take part in the hash, so boards that only differ
+// in their layout, terrain or tile variations have the same hash as well.
+func (b *Board) CanonicalHash() string {
+	sum := sha256.Sum256(b.canonicalForm())
+	return hex.EncodeToString(sum[:])
+}
+
+// IsSameTrack reports whether the two boards have the same track, in the
+// sense of CanonicalHash.
+func (b *Board) IsSameTrack(other *Board) bool {
+	return bytes.Equal(b.canonicalForm(), other.canonicalForm())
+}
+
+// canonicalForm returns the smallest encoding of the board among all of its
+// twelve symmetries.
+func (b *Board) canonicalForm() []byte {
+	var result []byte
+	for _, mirrored := range []bool{false, true} {
+		for steps := range 6 {
+			form, ok := b.symmetryForm(steps, mirrored)
+			if ok && (result == nil || bytes.Compare(form, result) < 0) {
+				result = form
+			}
+		}
+	}
+	return result
+}
+
+type canonicalTile struct {
+	offset Axial
+	tile   Tile
+}
+
+// symmetryForm returns the encoding of the board after it has been mirrored,
+// if requested, and rotated by the specified number of steps. It reports
+// false if the board has a tile that cannot be mirrored, in which case no
+// other board can be its mirror image either.
+func (b *Board) symmetryForm(steps int, mirrored bool) ([]byte, bool) {
+	center := b.Center().Axial()
+	coords := b.Coords()
+	tiles := make([]canonicalTile, 0, len(coords))
+	for _, coord := range coords {
+		tile := b.Tile(coord)
+		if !tile.HasAnyRoad() {
+			continue
+		}
+		offset := coord.Axial().Sub(center).Cube()
+		if mirrored {
+			offset = Cube{Q: offset.S, R: offset.R, S: offset.Q}
+			var err error
+			if tile, err = mirrorTile(tile); err != nil {
+				return nil, false
+			}
+		}
+		tile.Rotation = byte(((int(tile.Rotation)-steps)%6 + 6) % 6)
+		tiles = append(tiles, canonicalTile{
+			offset: offset.Rotate(steps).Axial(),
+			tile:   canonicalizeTile(tile),
+		})
+	}
+	slices.SortFunc(tiles, func(a, b canonicalTile) int {
+		return cmp.Or(
+			cmp.Compare(a.offset.R, b.offset.R),
+			cmp.Compare(a.offset.Q, b.offset.Q),
+		)
+	})
+
+	result := []byte{canonicalFormVersion, byte(b.biome)}
+	for _, entry := range tiles {
+		result = binary.AppendVarint(result, int64(entry.offset.Q))
+		result = binary.AppendVarint(result, int64(entry.offset.R))
+		result = append(result,
+			byte(entry.tile.Shape),
+			byte(entry.tile.Ground),
+			byte(entry.tile.Road),
+			entry.tile.Rotation,
+			entry.tile.Elevation,
+		)
+	}
+	return result, true
+}
+
+// canonicalizeTile clears the variation of the tile and picks the smallest
+// rotation that leaves its roads unchanged, so that symmetric shapes, such
+// as straights, have a single representation.
+func canonicalizeTile(tile Tile) Tile {
+	tile.Variation = 0
+	for rotation := range byte(6) {
+		candidate := tile
+		candidate.Rotation = rotation
+		if tileSidesMatch(tile, candidate, func(direction byte) byte { return direction }) {
+			return candidate
+		}
+	}
+	return tile
+}
@@ -0,0 +1,47 @@
+package level_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestCanonicalHashIgnoresRotateAndMirror(t *testing.T) {
+	hexagon, err := level.NewGenerator(level.GeneratorConfig{
+		Seed:   1,
+		Layout: level.HexagonLayout(3),
+	}).Generate(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	boards := map[string]*level.Board{
+		"oval":    ovalBoard(t),
+		"hexagon": hexagon,
+		"hills":   hillyBoard(t),
+	}
+	for name, board := range boards {
+		hash := board.CanonicalHash()
+		mirrored, err := board.Mirror()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for steps := range 6 {
+			for _, source := range []*level.Board{board, mirrored} {
+				transformed, err := source.Rotate(steps)
+				if err != nil {
+					t.Fatalf("%s, rotation by %d steps: %v", name, steps, err)
+				}
+				if actual := transformed.CanonicalHash(); actual != hash {
+					t.Errorf("%s, rotation by %d steps (mirrored: %t): hash changed from %s to %s", name, steps, source == mirrored, hash, actual)
+				}
+				if !transformed.IsSameTrack(board) {
+					t.Errorf("%s, rotation by %d steps (mirrored: %t): not the same track", name, steps, source == mirrored)
+				}
+			}
+		}
+	}
+	if boards["oval"].CanonicalHash() == boards["hexagon"].CanonicalHash() {
+		t.Errorf("different tracks have the same hash")
+	}
+}
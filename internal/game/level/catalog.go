@@ -0,0 +1,318 @@
+package level
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed catalog.json
+var defaultTileCatalogData []byte
+
+var defaultTileCatalog = mustLoadTileCatalog(defaultTileCatalogData)
+
+var ErrInvalidTileCatalog = errors.New("invalid tile catalog")
+
+// DefaultTileCatalog returns the catalog that is used by the Tile methods.
+func DefaultTileCatalog() *TileCatalog {
+	return defaultTileCatalog
+}
+
+// LoadTileCatalog parses a catalog in the JSON format of catalog.json.
+func LoadTileCatalog(data []byte) (*TileCatalog, error) {
+	var document tileCatalogDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTileCatalog, err)
+	}
+
+	catalog := &TileCatalog{
+		groundNames: make(map[GroundKind]string),
+		roadNames:   make(map[RoadKind]string),
+	}
+	shapeKinds := make(map[string]ShapeKind)
+	for _, shapeDocument := range document.Shapes {
+		kind := shapeDocument.Kind
+		if kind == ShapeKindNone || !kind.IsValid() {
+			return nil, fmt.Errorf("%w: shape %d is not known", ErrInvalidTileCatalog, kind)
+		}
+		if _, ok := catalog.shape(kind); ok {
+			return nil, fmt.Errorf("%w: shape %d is defined more than once", ErrInvalidTileCatalog, kind)
+		}
+		if shapeDocument.Name == "" {
+			return nil, fmt.Errorf("%w: shape %d has no name", ErrInvalidTileCatalog, kind)
+		}
+		shape := catalogShape{
+			defined:   true,
+			name:      shapeDocument.Name,
+			thumbnail: shapeDocument.Thumbnail,
+		}
+		for _, direction := range shapeDocument.Raised {
+			if direction < 0 || direction >= 6 {
+				return nil, fmt.Errorf("%w: shape %q has invalid raised directions", ErrInvalidTileCatalog, shape.name)
+			}
+			shape.raised |= 1 << direction
+		}
+		for _, road := range shapeDocument.Roads {
+			var group roadMask
+			for _, direction := range road {
+				if direction < 0 || direction >= 6 || shape.roads.contains(byte(direction)) || group.contains(byte(direction)) {
+					return nil, fmt.Errorf("%w: shape %q has invalid road directions", ErrInvalidTileCatalog, shape.name)
+				}
+				group |= 1 << direction
+			}
+			if group != 0 {
+				shape.roads |= group
+				shape.roadGroups = append(shape.roadGroups, group)
+			}
+		}
+		if int(kind) >= len(catalog.shapes) {
+			catalog.shapes = slices.Grow(catalog.shapes, int(kind)+1-len(catalog.shapes))[:int(kind)+1]
+		}
+		catalog.shapes[kind] = shape
+		catalog.shapeKinds = append(catalog.shapeKinds, kind)
+		shapeKinds[shape.name] = kind
+	}
+
+	groundKinds := make(map[string]GroundKind)
+	for _, groundDocument := range document.Grounds {
+		if groundDocument.Kind == GroundKindNone || !groundDocument.Kind.IsValid() || groundDocument.Name == "" {
+			return nil, fmt.Errorf("%w: ground %d is not valid", ErrInvalidTileCatalog, groundDocument.Kind)
+		}
+		catalog.groundNames[groundDocument.Kind] = groundDocument.Name
+		groundKinds[groundDocument.Name] = groundDocument.Kind
+	}
+	roadKinds := make(map[string]RoadKind)
+	for _, roadDocument := range document.Roads {
+		if roadDocument.Kind == RoadKindNone || !roadDocument.Kind.IsValid() || roadDocument.Name == "" {
+			return nil, fmt.Errorf("%w: road %d is not valid", ErrInvalidTileCatalog, roadDocument.Kind)
+		}
+		catalog.roadNames[roadDocument.Kind] = roadDocument.Name
+		roadKinds[roadDocument.Name] = roadDocument.Kind
+	}
+
+	for i, modelDocument := range document.Models {
+		if modelDocument.Template == "" || modelDocument.Variations < 1 || modelDocument.Variations > 256 {
+			return nil, fmt.Errorf("%w: model %d needs a template and between 1 and 256 variations", ErrInvalidTileCatalog, i)
+		}
+		model := catalogModel{
+			template:   modelDocument.Template,
+			variations: modelDocument.Variations,
+		}
+		for _, name := range modelDocument.Shapes {
+			kind, ok := shapeKinds[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: model %d refers to unknown shape %q", ErrInvalidTileCatalog, i, name)
+			}
+			model.shapes = append(model.shapes, kind)
+		}
+		for _, name := range modelDocument.Grounds {
+			kind, ok := groundKinds[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: model %d refers to unknown ground %q", ErrInvalidTileCatalog, i, name)
+			}
+			model.grounds = append(model.grounds, kind)
+		}
+		for _, name := range modelDocument.Roads {
+			kind, ok := roadKinds[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: model %d refers to unknown road %q", ErrInvalidTileCatalog, i, name)
+			}
+			model.roads = append(model.roads, kind)
+		}
+		// Models list the tiles that they represent explicitly, so that the
+		// catalog cannot claim models that do not exist.
+		if len(model.shapes) == 0 || len(model.grounds) == 0 {
+			return nil, fmt.Errorf("%w: model %d needs to list its shapes and grounds", ErrInvalidTileCatalog, i)
+		}
+		for _, kind := range model.shapes {
+			if catalog.shapes[kind].roads != 0 && len(model.roads) == 0 {
+				return nil, fmt.Errorf("%w: model %d needs to list its roads", ErrInvalidTileCatalog, i)
+			}
+		}
+		catalog.models = append(catalog.models, model)
+	}
+	return catalog, nil
+}
+
+func mustLoadTileCatalog(data []byte) *TileCatalog {
+	catalog, err := LoadTileCatalog(data)
+	if err != nil {
+		panic(err)
+	}
+	return catalog
+}
+
+// TileCatalog describes the available tiles. It specifies the roads of each
+// shape, the sides at which slopes are raised, the thumbnails that represent
+// the shapes and the models, along with their variations, that are used for
+// each combination of shape, ground and road.
+type TileCatalog struct {
+	shapes      []catalogShape
+	shapeKinds  []ShapeKind
+	groundNames map[GroundKind]string
+	roadNames   map[RoadKind]string
+	models      []catalogModel
+}
+
+// Shapes returns the shapes in the catalog in the order they are defined.
+func (c *TileCatalog) Shapes() []ShapeKind {
+	return slices.Clone(c.shapeKinds)
+}
+
+func (c *TileCatalog) Thumbnail(shape ShapeKind) (string, bool) {
+	catalogShape, ok := c.shape(shape)
+	if !ok || catalogShape.thumbnail == "" {
+		return "", false
+	}
+	return catalogShape.thumbnail, true
+}
+
+func (c *TileCatalog) HasRoad(tile Tile, direction byte) bool {
+	if int(tile.Shape) >= len(c.shapes) {
+		return false
+	}
+	return c.shapes[tile.Shape].roads.contains((direction + tile.Rotation) % 6)
+}
+
+// EdgeElevation returns the elevation level of the tile at its border in
+// the specified direction.
+func (c *TileCatalog) EdgeElevation(tile Tile, direction byte) int {
+	elevation := int(tile.Elevation)
+	if int(tile.Shape) < len(c.shapes) && c.shapes[tile.Shape].raised.contains((direction+tile.Rotation)%6) {
+		elevation++
+	}
+	return elevation
+}
+
+// RoadGroups returns the directions of the roads on the tile, grouped by
+// the roads that are connected to each other.
+func (c *TileCatalog) RoadGroups(tile Tile) [][]byte {
+	shape, ok := c.shape(tile.Shape)
+	if !ok {
+		return nil
+	}
+	result := make([][]byte, len(shape.roadGroups))
+	for i, group := range shape.roadGroups {
+		for direction := range byte(6) {
+			if group.contains((direction + tile.Rotation) % 6) {
+				result[i] = append(result[i], direction)
+			}
+		}
+	}
+	return result
+}
+
+// NodeName returns the name of the model node that represents the tile.
+// Empty tiles have no node and produce an empty name.
+func (c *TileCatalog) NodeName(tile Tile) (string, bool) {
+	if tile.Shape == ShapeKindNone {
+		return "", true
+	}
+	model, ok := c.model(tile)
+	if !ok {
+		return "", false
+	}
+	variation := int(tile.Variation)%model.variations + 1
+	return strings.NewReplacer(
+		"{shape}", c.shapes[tile.Shape].name,
+		"{ground}", c.groundNames[tile.Ground],
+		"{road}", c.roadNames[tile.Road],
+		"{variation}", strconv.Itoa(variation),
+	).Replace(model.template), true
+}
+
+// Variations returns the number of model variations that are available for
+// the tile.
+func (c *TileCatalog) Variations(tile Tile) int {
+	model, ok := c.model(tile)
+	if !ok {
+		return 0
+	}
+	return model.variations
+}
+
+func (c *TileCatalog) shape(kind ShapeKind) (catalogShape, bool) {
+	if int(kind) >= len(c.shapes) || !c.shapes[kind].defined {
+		return catalogShape{}, false
+	}
+	return c.shapes[kind], true
+}
+
+// model returns the first model that lists the shape, ground and road of
+// the tile. The road is only matched for shapes that have roads.
+func (c *TileCatalog) model(tile Tile) (catalogModel, bool) {
+	shape, ok := c.shape(tile.Shape)
+	if !ok {
+		return catalogModel{}, false
+	}
+	for _, model := range c.models {
+		if !slices.Contains(model.shapes, tile.Shape) || !slices.Contains(model.grounds, tile.Ground) {
+			continue
+		}
+		if shape.roads != 0 && !slices.Contains(model.roads, tile.Road) {
+			continue
+		}
+		return model, true
+	}
+	return catalogModel{}, false
+}
+
+type roadMask byte
+
+func (m roadMask) contains(direction byte) bool {
+	return m&(1<<direction) != 0
+}
+
+type catalogShape struct {
+	defined    bool
+	name       string
+	thumbnail  string
+	roads      roadMask
+	roadGroups []roadMask
+	raised     roadMask
+}
+
+type catalogModel struct {
+	shapes     []ShapeKind
+	grounds    []GroundKind
+	roads      []RoadKind
+	template   string
+	variations int
+}
+
+type tileCatalogDocument struct {
+	Shapes  []tileCatalogShapeDocument  `json:"shapes"`
+	Grounds []tileCatalogGroundDocument `json:"grounds"`
+	Roads   []tileCatalogRoadDocument   `json:"roads"`
+	Models  []tileCatalogModelDocument  `json:"models"`
+}
+
+type tileCatalogShapeDocument struct {
+	Kind      ShapeKind `json:"kind"`
+	Name      string    `json:"name"`
+	Roads     [][]int   `json:"roads"`
+	Raised    []int     `json:"raised"`
+	Thumbnail string    `json:"thumbnail"`
+}
+
+type tileCatalogGroundDocument struct {
+	Kind GroundKind `json:"kind"`
+	Name string     `json:"name"`
+}
+
+type tileCatalogRoadDocument struct {
+	Kind RoadKind `json:"kind"`
+	Name string   `json:"name"`
+}
+
+type tileCatalogModelDocument struct {
+	Shapes     []string `json:"shapes"`
+	Grounds    []string `json:"grounds"`
+	Roads      []string `json:"roads"`
+	Template   string   `json:"template"`
+	Variations int      `json:"variations"`
+}
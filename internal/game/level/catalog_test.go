@@ -0,0 +1,58 @@
+package level_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestTileCatalogNodeName(t *testing.T) {
+	catalog := level.DefaultTileCatalog()
+	testCases := []struct {
+		tile     level.Tile
+		expected string
+		ok       bool
+	}{
+		{
+			tile:     level.Tile{Shape: level.ShapeKindTerrain, Ground: level.GroundKindGrass},
+			expected: "Tile.Grass.v1",
+			ok:       true,
+		},
+		{
+			tile:     level.Tile{Shape: level.ShapeKindRoadCornerSmooth, Ground: level.GroundKindGrass, Road: level.RoadKindDirt},
+			expected: "Tile.Grass.Dirt.Corner.Smooth.v1",
+			ok:       true,
+		},
+		{
+			tile: level.Tile{Shape: level.ShapeKindTerrain, Ground: level.GroundKindSand},
+		},
+		{
+			tile: level.Tile{Shape: level.ShapeKindRoadStraight, Ground: level.GroundKindGrass, Road: level.RoadKindAsphalt},
+		},
+		{
+			tile: level.Tile{Shape: level.ShapeKindRoadCrossroads, Ground: level.GroundKindGrass, Road: level.RoadKindDirt},
+		},
+	}
+	for _, testCase := range testCases {
+		name, ok := catalog.NodeName(testCase.tile)
+		if name != testCase.expected || ok != testCase.ok {
+			t.Errorf("tile %+v: got %q, %t, expected %q, %t", testCase.tile, name, ok, testCase.expected, testCase.ok)
+		}
+	}
+}
+
+func TestLoadTileCatalogRejectsWildcardModels(t *testing.T) {
+	documents := []string{
+		`{"shapes": [{"kind": 1, "name": "Terrain"}], "grounds": [{"kind": 1, "name": "Grass"}],
+			"models": [{"template": "Tile.{ground}", "variations": 1}]}`,
+		`{"shapes": [{"kind": 2, "name": "Straight", "roads": [[0, 3]]}], "grounds": [{"kind": 1, "name": "Grass"}],
+			"roads": [{"kind": 1, "name": "Dirt"}],
+			"models": [{"shapes": ["Straight"], "grounds": ["Grass"], "template": "Tile.{shape}", "variations": 1}]}`,
+	}
+	for i, document := range documents {
+		if _, err := level.LoadTileCatalog([]byte(document)); !errors.Is(err, level.ErrInvalidTileCatalog) {
+			t.Errorf("document %d: expected an invalid catalog error, got %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package level
+
+import "slices"
+
+// circuitSearchLimit caps the number of road graph edges that are explored
+// when looking for a circuit, since the number of simple paths can grow
+// exponentially with the number of junctions.
+const circuitSearchLimit = 1 << 16
+
+// FindCircuit searches for a closed route along the road that passes
+// through the start tile and is at least minLength tiles long. The route
+// does not pass through the same junction twice, though it can cross
+// itself on an overpass. The returned coordinates begin with the start tile
+// and follow the road until just before it is reached again.
+func (b *Board) FindCircuit(start Coord, minLength int) ([]Coord, bool) {
+	if !b.ContainsCoord(start) || !b.Tile(start).HasAnyRoad() {
+		return nil, false
+	}
+	graph := b.RoadGraph()
+	search := circuitSearch{
+		graph:        graph,
+		minLength:    minLength,
+		visitedNodes: make([]bool, len(graph.Nodes)),
+	}
+
+	var startEdges []int
+	if nodeIndex, ok := graph.NodeAt(start); ok {
+		startEdges = graph.Nodes[nodeIndex].Edges
+	} else {
+		for edgeIndex, edge := range graph.Edges {
+			// The start tile can be on more than one edge if it is an
+			// overpass.
+			if slices.Contains(edge.Coords, start) {
+				startEdges = append(startEdges, edgeIndex)
+			}
+		}
+	}
+	for _, edgeIndex := range startEdges {
+		edge := graph.Edges[edgeIndex]
+		search.steps = []circuitStep{{edgeIndex, false}}
+		search.visitedNodes[edge.To] = true
+		found := edge.From == edge.To && edge.Length() >= minLength ||
+			edge.From != edge.To && search.extend(edge.To, edge.From, edge.Length())
+		search.visitedNodes[edge.To] = false
+		if found {
+			return search.coords(start), true
+		}
+	}
+	return nil, false
+}
+
+type circuitSearch struct {
+	graph        *RoadGraph
+	minLength    int
+	visitedNodes []bool
+	steps        []circuitStep
+	budget       int
+}
+
+type circuitStep struct {
+	edgeIndex int
+	reversed  bool
+}
+
+func (s *circuitSearch) extend(nodeIndex, targetIndex, length int) bool {
+	for _, edgeIndex := range s.graph.Nodes[nodeIndex].Edges {
+		if s.budget++; s.budget > circuitSearchLimit {
+			return false
+		}
+		edge := s.graph.Edges[edgeIndex]
+		if edge.From == edge.To || edgeIndex == s.steps[len(s.steps)-1].edgeIndex {
+			continue
+		}
+		nextIndex, reversed := edge.To, false
+		if nextIndex == nodeIndex {
+			nextIndex, reversed = edge.From, true
+		}
+		if s.visitedNodes[nextIndex] {
+			continue
+		}
+		s.steps = append(s.steps, circuitStep{edgeIndex, reversed})
+		if nextIndex == targetIndex {
+			if length+edge.Length() >= s.minLength {
+				return true
+			}
+		} else {
+			s.visitedNodes[nextIndex] = true
+			found := s.extend(nextIndex, targetIndex, length+edge.Length())
+			s.visitedNodes[nextIndex] = false
+			if found {
+				return true
+			}
+		}
+		s.steps = s.steps[:len(s.steps)-1]
+	}
+	return false
+}
+
+func (s *circuitSearch) coords(start Coord) []Coord {
+	var result []Coord
+	for _, step := range s.steps {
+		coords := slices.Clone(s.graph.Edges[step.edgeIndex].Coords)
+		if step.reversed {
+			slices.Reverse(coords)
+		}
+		result = append(result, coords[:len(coords)-1]...)
+	}
+	startIndex := slices.Index(result, start)
+	return append(result[startIndex:], result[:startIndex]...)
+}
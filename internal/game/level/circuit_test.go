@@ -0,0 +1,73 @@
+package level_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestFindCircuitFromOverpass(t *testing.T) {
+	// The start tile is an overpass where one road is a spur between two
+	// dead ends and the other one is part of a loop. The board is rotated
+	// so that the start tile is found on edges in different orders.
+	center := level.C(3, 3)
+	roads := map[level.Coord][]byte{
+		level.C(4, 4): {4},
+		level.C(3, 2): {1},
+		level.C(4, 3): {3, 5},
+		level.C(5, 2): {2, 3},
+		level.C(4, 2): {0, 4},
+		level.C(3, 1): {1, 3},
+		level.C(2, 1): {0, 2},
+		level.C(2, 2): {1, 5},
+		level.C(2, 3): {0, 4},
+	}
+	for steps := range 6 {
+		board := level.NewBoard(level.SquareLayout(7))
+		board.SetTile(center, level.Tile{
+			Shape:    level.ShapeKindRoadOverpass,
+			Ground:   level.GroundKindGrass,
+			Road:     level.RoadKindDirt,
+			Rotation: byte(6-steps) % 6,
+		})
+		for coord, directions := range roads {
+			var rotated []byte
+			for _, direction := range directions {
+				rotated = append(rotated, (direction+byte(steps))%6)
+			}
+			board.SetTile(coord.Rotate(center, steps), roadTile(t, rotated...))
+		}
+
+		circuit, ok := board.FindCircuit(center, 0)
+		if !ok {
+			t.Errorf("rotation by %d steps: no circuit found", steps)
+			continue
+		}
+		if len(circuit) != 8 {
+			t.Errorf("rotation by %d steps: expected a circuit of 8 tiles, got %d", steps, len(circuit))
+		}
+	}
+}
+
+// roadTile returns a tile whose roads lead in exactly the specified
+// directions.
+func roadTile(t *testing.T, directions ...byte) level.Tile {
+	t.Helper()
+	for shape := level.ShapeKindRoadStraight; shape <= level.ShapeKindRoadDeadEnd; shape++ {
+		for rotation := range byte(6) {
+			tile := level.Tile{Shape: shape, Ground: level.GroundKindGrass, Road: level.RoadKindDirt, Rotation: rotation}
+			matches := true
+			for direction := range byte(6) {
+				if tile.HasRoad(direction) != slices.Contains(directions, direction) {
+					matches = false
+				}
+			}
+			if matches {
+				return tile
+			}
+		}
+	}
+	t.Fatalf("no tile has roads in directions %v", directions)
+	return level.Tile{}
+}
@@ -0,0 +1,138 @@
+package level
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+)
+
+// GeneratorVersion identifies the generation algorithm. It must be increased
+// whenever a change to the generator would produce a different board for the
+// same seed, so that old level codes are rejected instead of silently
+// producing a different track.
+const GeneratorVersion = 1
+
+const (
+	legacyLevelCodeLength = 11
+	levelCodeLength       = 13
+	levelCodeGroupSize    = 6
+)
+
+var levelCodeEncoding = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+// levelCodeMaxSize is the largest width, height or radius that fits in the
+// single byte that a level code reserves for it.
+const levelCodeMaxSize = 255
+
+var ErrInvalidLevelCode = errors.New("invalid level code")
+
+func NewLevelCode(layout Layout, seed uint64) (LevelCode, error) {
+	if layout.Width > levelCodeMaxSize || layout.Height > levelCodeMaxSize || layout.Radius > levelCodeMaxSize {
+		return LevelCode{}, fmt.Errorf("%w: layout of %dx%d tiles is too large", ErrInvalidLevelCode, layout.Width, layout.Height)
+	}
+	return LevelCode{
+		Version: GeneratorVersion,
+		Layout:  layout,
+		Seed:    seed,
+	}, nil
+}
+
+// LevelCode is a short human-shareable representation of all the inputs
+// that the generator needs in order to reproduce a board.
+type LevelCode struct {
+	Version byte
+	Layout  Layout
+	Biome   BiomeKind
+	Seed    uint64
+}
+
+func (c LevelCode) String() string {
+	data := make([]byte, 0, levelCodeLength)
+	// The biome shares a byte with the layout kind, so that codes from
+	// before biomes were introduced remain valid and produce meadows.
+	data = append(data, c.Version, byte(c.Layout.Kind)|byte(c.Biome)<<4)
+	switch c.Layout.Kind {
+	case LayoutKindHexagon:
+		data = append(data, byte(c.Layout.Radius), 0)
+	default:
+		data = append(data, byte(c.Layout.Width), byte(c.Layout.Height))
+	}
+	data = binary.BigEndian.AppendUint64(data, c.Seed)
+	data = append(data, levelCodeChecksum(data))
+
+	text := levelCodeEncoding.EncodeToString(data)
+	var groups []string
+	for len(text) > levelCodeGroupSize {
+		groups = append(groups, text[:levelCodeGroupSize])
+		text = text[levelCodeGroupSize:]
+	}
+	groups = append(groups, text)
+	return strings.Join(groups, "-")
+}
+
+func (c LevelCode) Generate(ctx context.Context) (*Board, error) {
+	if c.Version != GeneratorVersion {
+		return nil, fmt.Errorf("level code is for generator version %d but version %d is used", c.Version, GeneratorVersion)
+	}
+	generator := NewGenerator(GeneratorConfig{
+		Seed:   c.Seed,
+		Layout: c.Layout,
+		Biome:  c.Biome,
+	})
+	return generator.Generate(ctx)
+}
+
+func ParseLevelCode(text string) (LevelCode, error) {
+	text = strings.ToUpper(text)
+	text = strings.NewReplacer("-", "", " ", "", "O", "0", "I", "1", "L", "1").Replace(text)
+	data, err := levelCodeEncoding.DecodeString(text)
+	if err != nil || (len(data) != levelCodeLength && len(data) != legacyLevelCodeLength) {
+		return LevelCode{}, ErrInvalidLevelCode
+	}
+	if levelCodeChecksum(data[:len(data)-1]) != data[len(data)-1] {
+		return LevelCode{}, fmt.Errorf("%w: checksum mismatch", ErrInvalidLevelCode)
+	}
+
+	// Legacy codes were introduced before the layout could be specified
+	// and only hold the size of a square board.
+	if len(data) == legacyLevelCodeLength {
+		code := LevelCode{
+			Version: data[0],
+			Layout:  SquareLayout(int(data[1])),
+			Seed:    binary.BigEndian.Uint64(data[2:10]),
+		}
+		if code.Layout.Width == 0 {
+			return LevelCode{}, fmt.Errorf("%w: zero size", ErrInvalidLevelCode)
+		}
+		return code, nil
+	}
+
+	code := LevelCode{
+		Version: data[0],
+		Biome:   BiomeKind(data[1] >> 4),
+		Seed:    binary.BigEndian.Uint64(data[4:12]),
+	}
+	if !code.Biome.IsValid() {
+		return LevelCode{}, fmt.Errorf("%w: unknown biome %d", ErrInvalidLevelCode, code.Biome)
+	}
+	switch kind := LayoutKind(data[1] & 0x0F); kind {
+	case LayoutKindRectangle:
+		code.Layout = RectangleLayout(int(data[2]), int(data[3]))
+	case LayoutKindHexagon:
+		code.Layout = HexagonLayout(int(data[2]))
+	default:
+		return LevelCode{}, fmt.Errorf("%w: unknown layout %d", ErrInvalidLevelCode, kind)
+	}
+	if code.Layout.Width == 0 || code.Layout.Height == 0 {
+		return LevelCode{}, fmt.Errorf("%w: zero size", ErrInvalidLevelCode)
+	}
+	return code, nil
+}
+
+func levelCodeChecksum(data []byte) byte {
+	return byte(crc32.ChecksumIEEE(data))
+}
@@ -0,0 +1,53 @@
+package level_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestLevelCodeRoundTrip(t *testing.T) {
+	layouts := []level.Layout{
+		level.SquareLayout(9),
+		level.RectangleLayout(255, 3),
+		level.HexagonLayout(5),
+	}
+	for _, layout := range layouts {
+		code, err := level.NewLevelCode(layout, 0xDEADBEEF)
+		if err != nil {
+			t.Fatalf("layout %+v: %v", layout, err)
+		}
+		parsed, err := level.ParseLevelCode(code.String())
+		if err != nil {
+			t.Fatalf("layout %+v: %v", layout, err)
+		}
+		if parsed != code {
+			t.Errorf("layout %+v: parsed %+v, expected %+v", layout, parsed, code)
+		}
+	}
+}
+
+func TestParseLevelCodeRejectsUnknownBiome(t *testing.T) {
+	code, err := level.NewLevelCode(level.SquareLayout(9), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code.Biome = level.BiomeKindMeadow + 1
+	if _, err := level.ParseLevelCode(code.String()); !errors.Is(err, level.ErrInvalidLevelCode) {
+		t.Errorf("expected an invalid level code error, got %v", err)
+	}
+}
+
+func TestNewLevelCodeRejectsLargeLayouts(t *testing.T) {
+	layouts := []level.Layout{
+		level.RectangleLayout(300, 9),
+		level.RectangleLayout(9, 256),
+		level.HexagonLayout(128),
+	}
+	for _, layout := range layouts {
+		if _, err := level.NewLevelCode(layout, 1); !errors.Is(err, level.ErrInvalidLevelCode) {
+			t.Errorf("layout %+v: expected an invalid level code error, got %v", layout, err)
+		}
+	}
+}
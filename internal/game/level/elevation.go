@@ -0,0 +1,78 @@
+package level
+
+import "math/rand/v2"
+
+// raiseHills turns pairs of straight road tiles along the road graph edges
+// into slopes and raises the road between them, nesting hills up to the
+// specified elevation. Tiles for which keep returns true, as well as tiles
+// that are part of more than one edge, are not changed, so that the road
+// stays continuous.
+func raiseHills(board *Board, maxElevation int, random *rand.Rand, keep func(Coord) bool) {
+	maxElevation = min(maxElevation, MaxTileElevation)
+	if maxElevation <= 0 {
+		return
+	}
+
+	graph := board.RoadGraph()
+	edgeCounts := make(map[Coord]int)
+	for _, edge := range graph.Edges {
+		for _, coord := range edge.Coords {
+			edgeCounts[coord]++
+		}
+	}
+	canRaise := func(coord Coord) bool {
+		return edgeCounts[coord] == 1 && !keep(coord) && coord != board.Center()
+	}
+	canSlope := func(coord Coord) bool {
+		return canRaise(coord) && board.Tile(coord).Shape == ShapeKindRoadStraight
+	}
+
+	for _, edge := range graph.Edges {
+		first, last := 1, len(edge.Coords)-2
+		for range maxElevation {
+			if random.IntN(2) == 0 {
+				break
+			}
+			var slopeIndices []int
+			for i := first; i <= last; i++ {
+				if !canRaise(edge.Coords[i]) {
+					// Only a contiguous stretch of the edge can be raised.
+					if len(slopeIndices) >= 2 {
+						break
+					}
+					slopeIndices = slopeIndices[:0]
+					continue
+				}
+				if canSlope(edge.Coords[i]) {
+					slopeIndices = append(slopeIndices, i)
+				}
+			}
+			if len(slopeIndices) < 2 || slopeIndices[len(slopeIndices)-1]-slopeIndices[0] < 2 {
+				break
+			}
+			var lower, upper int
+			for upper-lower < 2 {
+				lower = slopeIndices[random.IntN(len(slopeIndices))]
+				upper = slopeIndices[random.IntN(len(slopeIndices))]
+			}
+			board.setSlope(edge.Coords[lower], edge.Coords[lower+1])
+			board.setSlope(edge.Coords[upper], edge.Coords[upper-1])
+			for i := lower + 1; i < upper; i++ {
+				tile := board.Tile(edge.Coords[i])
+				tile.Elevation++
+				board.SetTile(edge.Coords[i], tile)
+			}
+			first, last = lower+1, upper-1
+		}
+	}
+}
+
+// setSlope replaces the straight road at the specified coordinate with a
+// slope that is raised towards the specified neighbor.
+func (b *Board) setSlope(coord, raisedNeighbor Coord) {
+	direction, _ := coord.DirectionTo(raisedNeighbor)
+	tile := b.Tile(coord)
+	tile.Shape = ShapeKindRoadSlope
+	tile.Rotation = (6 - direction) % 6
+	b.SetTile(coord, tile)
+}
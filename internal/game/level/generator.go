@@ -1,71 +1,520 @@
 package level
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
 	"math/rand/v2"
+	"slices"
+	"time"
 )
 
+// generatorCancelCheckInterval controls how many tile attempts are made
+// between checks of the context, since checking on every attempt is costly.
+const generatorCancelCheckInterval = 1024
+
+// defaultRoadDensityTolerance is used when the config specifies a road
+// density target but no tolerance.
+const defaultRoadDensityTolerance = 0.1
+
+// generatorMaxRetries limits how many complete boards are generated in
+// search of one that satisfies the requirements that can only be checked
+// once the board is complete.
+const generatorMaxRetries = 64
+
+var (
+	ErrGenerationFailed         = errors.New("failed to generate a level")
+	ErrUnsatisfiableConstraints = errors.New("generator constraints cannot be satisfied")
+)
+
+const (
+	// RoadNetworkKindAny allows the board to contain road networks that are
+	// not connected to the start tile.
+	RoadNetworkKindAny RoadNetworkKind = iota
+
+	// RoadNetworkKindConnected requires all roads on the board to be
+	// connected to the start tile. Boards that do not satisfy this are
+	// discarded, which makes it impractical for large boards.
+	RoadNetworkKindConnected
+
+	// RoadNetworkKindPruned turns roads that are not connected to the start
+	// tile into terrain.
+	RoadNetworkKindPruned
+)
+
+type RoadNetworkKind byte
+
 type GeneratorConfig struct {
-	Random *rand.Rand
-	Size   int
+	Random     *rand.Rand
+	Seed       uint64
+	Layout     Layout
+	TimeBudget time.Duration
+
+	// Pinned is an optional board with the same layout, whose non-empty
+	// tiles are kept as they are, while the rest of the board is generated
+	// around them.
+	Pinned *Board
+
+	// ForbiddenShapes optionally specifies shapes that must not be placed
+	// at the respective coordinates.
+	ForbiddenShapes map[Coord]ShapeMask
+
+	// ShapeWeights optionally controls how likely each shape is to be
+	// tried first. Shapes without a positive weight are not used at all.
+	// When nil, all basic shapes are equally likely and the crossroads,
+	// junction, dead end and overpass shapes are not used. Shapes without a
+	// tile model are rejected.
+	ShapeWeights map[ShapeKind]float64
+
+	// RoadDensity is the target fraction of tiles that have a road on them.
+	// Zero means that no target is used. Boards whose density differs from
+	// the target by more than RoadDensityTolerance are discarded, with zero
+	// meaning a tolerance of 0.1. The generators steer towards the target,
+	// but a narrow tolerance can still require many retries.
+	RoadDensity          float64
+	RoadDensityTolerance float64
+
+	// MaxShapeCounts optionally limits how many tiles of a given shape can
+	// be placed on the board. Road ends always connect in pairs, so when
+	// only one shape with an odd number of road ends can be placed, an odd
+	// limit for it is lowered by one.
+	MaxShapeCounts map[ShapeKind]int
+
+	// RequireCircuit specifies that a closed circuit of at least
+	// MinLapLength tiles must pass through the start tile.
+	RequireCircuit bool
+	MinLapLength   int
+
+	// RoadNetwork controls how roads that cannot be reached from the start
+	// tile are handled.
+	RoadNetwork RoadNetworkKind
+
+	// Biome determines the grounds and roads of the generated tiles.
+	Biome BiomeKind
+
+	// MaxElevation is the maximum number of levels that hills along the
+	// roads can rise to. Zero means that the board is flat. Only the road
+	// tiles of a hill are raised, so the terrain next to them stays flat
+	// and raised roads sit above their surroundings.
+	MaxElevation int
+
+	// MinDifficulty and MaxDifficulty optionally limit the difficulty of
+	// the track, as reported by Board.Metrics. A MaxDifficulty of zero means
+	// that there is no upper limit. Boards outside of the range are
+	// discarded, so a narrow range can require many retries.
+	MinDifficulty float64
+	MaxDifficulty float64
+}
+
+var generatorShapes = []ShapeKind{
+	ShapeKindTerrain,
+	ShapeKindRoadStraight,
+	ShapeKindRoadCornerSmooth,
+	ShapeKindRoadCornerSharp,
+	ShapeKindRoadSplit,
+}
+
+// generatorOptionalShapes are only used when they are given a positive
+// weight, so that the default generator output does not change.
+var generatorOptionalShapes = []ShapeKind{
+	ShapeKindRoadCrossroads,
+	ShapeKindRoadJunction,
+	ShapeKindRoadDeadEnd,
+	ShapeKindRoadOverpass,
+}
+
+func (c GeneratorConfig) checkConstraints() error {
+	if !c.Layout.isConsistent() {
+		return fmt.Errorf("%w: layout %d with size %dx%d and radius %d is not valid", ErrUnsatisfiableConstraints, c.Layout.Kind, c.Layout.Width, c.Layout.Height, c.Layout.Radius)
+	}
+	if !c.Biome.IsValid() {
+		return fmt.Errorf("%w: biome %d is not known", ErrUnsatisfiableConstraints, c.Biome)
+	}
+	if c.MaxDifficulty > 0 && c.MinDifficulty > c.MaxDifficulty {
+		return fmt.Errorf("%w: minimum difficulty is above the maximum", ErrUnsatisfiableConstraints)
+	}
+	if err := c.checkTileModels(); err != nil {
+		return err
+	}
+	if c.Pinned == nil {
+		return nil
+	}
+	if c.Pinned.Layout() != c.Layout {
+		return fmt.Errorf("%w: pinned board layout does not match generator layout", ErrUnsatisfiableConstraints)
+	}
+	pinnedCounts := make(map[ShapeKind]int)
+	for _, coord := range c.Pinned.Coords() {
+		if c.isPinned(coord) {
+			pinnedCounts[c.Pinned.Tile(coord).Shape]++
+		}
+	}
+	for shape, maxCount := range c.MaxShapeCounts {
+		if pinnedCounts[shape] > maxCount {
+			return fmt.Errorf("%w: there are %d pinned tiles of shape %d but at most %d are allowed", ErrUnsatisfiableConstraints, pinnedCounts[shape], shape, maxCount)
+		}
+	}
+	for _, coord := range c.Pinned.Coords() {
+		if !c.isPinned(coord) {
+			continue
+		}
+		tile := c.Pinned.Tile(coord)
+		if problems := c.Pinned.validateTileKinds(coord); len(problems) > 0 {
+			return fmt.Errorf("%w: pinned tile %s", ErrUnsatisfiableConstraints, problems[0])
+		}
+		if _, ok := tile.nodeName(); !ok {
+			return fmt.Errorf("%w: pinned tile at %s has no model", ErrUnsatisfiableConstraints, coord)
+		}
+		if c.ForbiddenShapes[coord].Contains(tile.Shape) {
+			return fmt.Errorf("%w: pinned tile at %s has a forbidden shape", ErrUnsatisfiableConstraints, coord)
+		}
+		if coord == c.Pinned.Center() && !isValidStartTile(tile) {
+			return fmt.Errorf("%w: pinned tile at %s is not a valid start tile", ErrUnsatisfiableConstraints, coord)
+		}
+		for direction := range byte(6) {
+			neighborCoord := coord.Neighbor(direction)
+			switch {
+			case !c.Pinned.ContainsCoord(neighborCoord):
+				if tile.HasRoad(direction) {
+					return fmt.Errorf("%w: pinned tile at %s has a road leading off the board", ErrUnsatisfiableConstraints, coord)
+				}
+			case c.isPinned(neighborCoord):
+				neighborTile := c.Pinned.Tile(neighborCoord)
+				if tile.HasRoad(direction) != neighborTile.HasRoad(oppositeDirection(direction)) {
+					return fmt.Errorf("%w: pinned tiles at %s and %s do not connect", ErrUnsatisfiableConstraints, coord, neighborCoord)
+				}
+				if !GroundsCanTouch(tile.Ground, neighborTile.Ground) || tile.HasRoad(direction) && !RoadsCanConnect(tile.Road, neighborTile.Road) {
+					return fmt.Errorf("%w: pinned tiles at %s and %s have incompatible surfaces", ErrUnsatisfiableConstraints, coord, neighborCoord)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkTileModels verifies that the catalog has a model for every tile that
+// the generator can produce, since the game cannot render the others.
+func (c GeneratorConfig) checkTileModels() error {
+	surfaces := biomes[c.Biome]
+	grounds := []GroundKind{surfaces.ground}
+	if surfaces.patchGround != GroundKindNone {
+		grounds = append(grounds, surfaces.patchGround)
+	}
+	shapes := c.shapes()
+	if c.MaxElevation > 0 {
+		shapes = append(slices.Clone(shapes), ShapeKindRoadSlope)
+	}
+	for _, shape := range shapes {
+		for _, ground := range grounds {
+			for _, road := range surfaces.roads {
+				tile := Tile{Shape: shape, Ground: ground, Road: road}
+				if _, ok := tile.nodeName(); !ok {
+					return fmt.Errorf("%w: there is no model for shape %d with ground %d and road %d", ErrUnsatisfiableConstraints, shape, ground, road)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// finishBoard verifies the requirements that can only be checked on a
+// complete board and applies any final adjustments to it.
+func (c GeneratorConfig) finishBoard(board *Board, random *rand.Rand) error {
+	switch c.RoadNetwork {
+	case RoadNetworkKindConnected:
+		if components := board.RoadComponents(); len(components) > 1 {
+			return fmt.Errorf("road network is split into %d components", len(components))
+		}
+	case RoadNetworkKindPruned:
+		for _, component := range board.RoadComponents() {
+			if slices.Contains(component, board.Center()) {
+				continue
+			}
+			for _, coord := range component {
+				if c.isPinned(coord) {
+					return fmt.Errorf("pinned tile at %s is not connected to the start tile", coord)
+				}
+			}
+		}
+		board.PruneRoadIslands(board.Center())
+	}
+	if c.RoadDensity > 0.0 {
+		tolerance := c.RoadDensityTolerance
+		if tolerance <= 0.0 {
+			tolerance = defaultRoadDensityTolerance
+		}
+		if density := board.RoadDensity(); math.Abs(density-c.RoadDensity) > tolerance {
+			return fmt.Errorf("road density %.2f is outside of the requested range", density)
+		}
+	}
+	if c.RequireCircuit {
+		if _, ok := board.FindCircuit(board.Center(), c.MinLapLength); !ok {
+			return fmt.Errorf("no circuit of at least %d tiles passes through the start tile", c.MinLapLength)
+		}
+	}
+	raiseHills(board, c.MaxElevation, random, c.isPinned)
+	if c.MinDifficulty > 0 || c.MaxDifficulty > 0 {
+		difficulty := board.Metrics().Difficulty
+		if difficulty < c.MinDifficulty || c.MaxDifficulty > 0 && difficulty > c.MaxDifficulty {
+			return fmt.Errorf("track difficulty %.1f is outside of the requested range", difficulty)
+		}
+	}
+	board.SetBiome(c.Biome)
+	paintSurfaces(board, c.Biome, random, c.isPinned)
+	assignVariations(board, defaultTileCatalog, random, c.isPinned)
+	if c.Pinned != nil {
+		// Pinned tiles can have elevations or surfaces that the generated
+		// tiles around them do not match.
+		return validateBoard(board)
+	}
+	return nil
+}
+
+// assignVariations picks a random model variation for each tile that has
+// more than one available in the catalog.
+func assignVariations(board *Board, catalog *TileCatalog, random *rand.Rand, keep func(Coord) bool) {
+	for _, coord := range board.Coords() {
+		if keep(coord) {
+			continue
+		}
+		tile := board.Tile(coord)
+		if variations := catalog.Variations(tile); variations > 1 {
+			tile.Variation = byte(random.IntN(variations))
+			board.SetTile(coord, tile)
+		}
+	}
+}
+
+func (c GeneratorConfig) isPinned(coord Coord) bool {
+	return c.Pinned != nil && c.Pinned.ContainsCoord(coord) && c.Pinned.Tile(coord).Shape != ShapeKindNone
+}
+
+func (c GeneratorConfig) hasConstraints() bool {
+	return c.Pinned != nil || len(c.ForbiddenShapes) > 0 || len(c.MaxShapeCounts) > 0
+}
+
+func (c GeneratorConfig) random() *rand.Rand {
+	if c.Random != nil {
+		return c.Random
+	}
+	return NewRandom(c.Seed)
+}
+
+// shapeCaps returns the shape limits that the generators enforce. Every
+// road end needs a matching one on the neighboring tile, so a board always
+// has an even number of tiles with an odd number of road ends. When only one
+// such shape can be placed, an odd limit for it cannot be reached and
+// searching for a board that has that many would be futile.
+func (c GeneratorConfig) shapeCaps() map[ShapeKind]int {
+	if len(c.MaxShapeCounts) == 0 {
+		return c.MaxShapeCounts
+	}
+	oddShapes := make(map[ShapeKind]struct{})
+	for _, shape := range c.shapes() {
+		if roadEndCount(shape)%2 == 1 {
+			oddShapes[shape] = struct{}{}
+		}
+	}
+	if c.Pinned != nil {
+		for _, coord := range c.Pinned.Coords() {
+			if shape := c.Pinned.Tile(coord).Shape; c.isPinned(coord) && roadEndCount(shape)%2 == 1 {
+				oddShapes[shape] = struct{}{}
+			}
+		}
+	}
+	result := maps.Clone(c.MaxShapeCounts)
+	if len(oddShapes) == 1 {
+		for shape := range oddShapes {
+			if maxCount, ok := result[shape]; ok && maxCount%2 == 1 {
+				result[shape] = maxCount - 1
+			}
+		}
+	}
+	return result
+}
+
+func roadEndCount(shape ShapeKind) int {
+	tile := Tile{Shape: shape}
+	var result int
+	for direction := range byte(6) {
+		if tile.HasRoad(direction) {
+			result++
+		}
+	}
+	return result
+}
+
+// shapes returns the shapes that can be used by the generator, in a stable
+// order.
+func (c GeneratorConfig) shapes() []ShapeKind {
+	if c.ShapeWeights == nil {
+		return generatorShapes
+	}
+	var result []ShapeKind
+	for _, shape := range slices.Concat(generatorShapes, generatorOptionalShapes) {
+		if c.ShapeWeights[shape] > 0.0 {
+			result = append(result, shape)
+		}
+	}
+	return result
 }
 
 func NewGenerator(config GeneratorConfig) *Generator {
-	shapeSequences := make([][]ShapeKind, config.Size*config.Size)
+	config.MaxShapeCounts = config.shapeCaps()
+	// Generate reports layouts that are not valid, so no tiles are prepared
+	// for them.
+	var tileCount int
+	if config.Layout.isConsistent() {
+		tileCount = config.Layout.Width * config.Layout.Height
+	}
+
+	shapes := config.shapes()
+	shapeSequences := make([][]ShapeKind, tileCount)
 	for i := range shapeSequences {
-		shapeSequences[i] = []ShapeKind{
-			ShapeKindTerrain,
-			ShapeKindRoadStraight,
-			ShapeKindRoadCornerSmooth,
-			ShapeKindRoadCornerSharp,
-			ShapeKindRoadSplit,
-		}
+		shapeSequences[i] = slices.Clone(shapes)
 	}
 
-	rotationSequences := make([][]byte, config.Size*config.Size)
+	rotationSequences := make([][]byte, tileCount)
 	for i := range rotationSequences {
 		rotationSequences[i] = []byte{0, 1, 2, 3, 4, 5}
 	}
 
 	return &Generator{
-		random:            config.Random,
+		config:            config,
+		random:            config.random(),
 		shapeSequences:    shapeSequences,
 		rotationSequences: rotationSequences,
-		boardSize:         config.Size,
+		shapeCounts:       make(map[ShapeKind]int),
 	}
 }
 
+func NewRandom(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
 type Generator struct {
+	config            GeneratorConfig
 	random            *rand.Rand
 	shapeSequences    [][]ShapeKind
 	rotationSequences [][]byte
-	boardSize         int
+
+	shapeCounts map[ShapeKind]int
+	stats       GeneratorStats
+}
+
+// GeneratorStats describes the amount of work that was needed to produce
+// the last board.
+type GeneratorStats struct {
+	Attempts     int
+	Backtracks   int
+	Retries      int
+	DeepestIndex int
+	Duration     time.Duration
+}
+
+func (g *Generator) Stats() GeneratorStats {
+	return g.stats
+}
+
+func (g *Generator) Generate(ctx context.Context) (*Board, error) {
+	if g.config.TimeBudget > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, g.config.TimeBudget)
+		defer cancel()
+	}
+	g.stats = GeneratorStats{}
+
+	startTime := time.Now()
+	defer func() {
+		g.stats.Duration = time.Since(startTime)
+	}()
+
+	if err := g.config.checkConstraints(); err != nil {
+		return nil, err
+	}
+	return generateWithRetries(ctx, g.config, g.random, &g.stats, g.generateBoard)
 }
 
-func (g *Generator) Generate() *Board {
+func (g *Generator) generateBoard(ctx context.Context) (*Board, error) {
 	for _, shapeSequence := range g.shapeSequences {
-		shuffleSlice(g.random, shapeSequence)
+		if g.config.ShapeWeights != nil {
+			weightedShuffleSlice(g.random, shapeSequence, g.config.ShapeWeights)
+		} else {
+			shuffleSlice(g.random, shapeSequence)
+		}
 	}
 	for _, rotationSequence := range g.rotationSequences {
 		shuffleSlice(g.random, rotationSequence)
 	}
 
-	board := NewBoard(g.boardSize)
-	if !g.generateTile(board, 0) {
-		panic("failed to generate a level")
+	clear(g.shapeCounts)
+	board := NewBoard(g.config.Layout)
+	if g.config.Pinned != nil {
+		for _, coord := range board.Coords() {
+			if g.config.isPinned(coord) {
+				tile := g.config.Pinned.Tile(coord)
+				board.SetTile(coord, tile)
+				g.shapeCounts[tile.Shape]++
+			}
+		}
+	}
+	ok, err := g.generateTile(ctx, board, 0)
+	if err != nil {
+		return nil, fmt.Errorf("generation interrupted after %d attempts: %w", g.stats.Attempts, err)
+	}
+	if !ok {
+		if g.config.hasConstraints() {
+			return nil, fmt.Errorf("%w: no board matches the pinned tiles and forbidden shapes", ErrUnsatisfiableConstraints)
+		}
+		return nil, ErrGenerationFailed
 	}
-	return board
+	return board, nil
 }
 
-func (g *Generator) generateTile(board *Board, index int) bool {
-	if index >= g.boardSize*g.boardSize {
-		return true
+// generateWithRetries keeps generating boards until one satisfies the
+// requirements of the config that are checked on complete boards.
+func generateWithRetries(ctx context.Context, config GeneratorConfig, random *rand.Rand, stats *GeneratorStats, generate func(ctx context.Context) (*Board, error)) (*Board, error) {
+	for {
+		board, err := generate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		checkErr := config.finishBoard(board, random)
+		if checkErr == nil {
+			return board, nil
+		}
+		if stats.Retries >= generatorMaxRetries {
+			return nil, fmt.Errorf("%w after %d retries: %w", ErrGenerationFailed, stats.Retries, checkErr)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("generation interrupted after %d retries: %w", stats.Retries, err)
+		}
+		stats.Retries++
+	}
+}
+
+func (g *Generator) generateTile(ctx context.Context, board *Board, index int) (bool, error) {
+	g.stats.DeepestIndex = max(g.stats.DeepestIndex, index)
+	if index >= g.config.Layout.Width*g.config.Layout.Height {
+		return true, nil
 	}
 
-	coord := C(index%g.boardSize, index/g.boardSize)
+	coord := C(index%g.config.Layout.Width, index/g.config.Layout.Width)
+	if !board.ContainsCoord(coord) || g.config.isPinned(coord) {
+		return g.generateTile(ctx, board, index+1)
+	}
 	shapeSequence := g.shapeSequences[index]
+	if g.config.RoadDensity > 0.0 {
+		shapeSequence = g.densitySortedShapes(shapeSequence)
+	}
 	rotationSequence := g.rotationSequences[index]
 
 	for _, shape := range shapeSequence {
+		// Rotations that produce the same roads, like all rotations of the
+		// terrain, lead to the same outcome. Only the first one that fits
+		// is tried, since the rest would fail in the same way.
+		var triedRoads []roadMask
 		for _, rotation := range rotationSequence {
 			tile := Tile{
 				Shape:     shape,
@@ -74,25 +523,71 @@ func (g *Generator) generateTile(board *Board, index int) bool {
 				Variation: 0,
 				Rotation:  rotation,
 			}
+			g.stats.Attempts++
+			if g.stats.Attempts%generatorCancelCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return false, err
+				}
+			}
 			if !g.canPlaceTile(board, tile, coord) {
 				continue
 			}
+			roads := tile.roadMask()
+			if slices.Contains(triedRoads, roads) {
+				continue
+			}
+			triedRoads = append(triedRoads, roads)
 			board.SetTile(coord, tile)
-			if g.generateTile(board, index+1) {
-				return true
+			g.shapeCounts[shape]++
+			ok, err := g.generateTile(ctx, board, index+1)
+			if err != nil || ok {
+				return ok, err
 			}
+			g.shapeCounts[shape]--
+			g.stats.Backtracks++
+		}
+	}
+	board.SetTile(coord, Tile{})
+	return false, nil
+}
+
+// densitySortedShapes reorders the shapes so that terrain is tried first
+// when the board has more roads than the target density and last when it
+// has fewer.
+func (g *Generator) densitySortedShapes(shapes []ShapeKind) []ShapeKind {
+	var placedCount int
+	for _, count := range g.shapeCounts {
+		placedCount += count
+	}
+	roadCount := placedCount - g.shapeCounts[ShapeKindTerrain]
+	preferRoads := float64(roadCount) < g.config.RoadDensity*float64(placedCount+1)
+
+	result := make([]ShapeKind, 0, len(shapes))
+	for _, shape := range shapes {
+		if (shape == ShapeKindTerrain) != preferRoads {
+			result = append(result, shape)
 		}
 	}
-	return false
+	for _, shape := range shapes {
+		if (shape == ShapeKindTerrain) == preferRoads {
+			result = append(result, shape)
+		}
+	}
+	return result
 }
 
 func (g *Generator) canPlaceTile(board *Board, tile Tile, coord Coord) bool {
 	// Check starting tile.
-	if coord == board.Center() {
-		isValid := tile.Shape == ShapeKindRoadStraight && (tile.Rotation == 0 || tile.Rotation == 3)
-		if !isValid {
-			return false
-		}
+	if coord == board.Center() && !isValidStartTile(tile) {
+		return false
+	}
+	// Check forbidden shapes.
+	if g.config.ForbiddenShapes[coord].Contains(tile.Shape) {
+		return false
+	}
+	// Check shape limits.
+	if maxCount, ok := g.config.MaxShapeCounts[tile.Shape]; ok && g.shapeCounts[tile.Shape] >= maxCount {
+		return false
 	}
 	// Check connection to the left.
 	if !g.isValidConnection(board, tile, coord, 3) {
@@ -106,6 +601,12 @@ func (g *Generator) canPlaceTile(board *Board, tile Tile, coord Coord) bool {
 	if !g.isValidConnection(board, tile, coord, 5) {
 		return false
 	}
+	// Check connections to pinned tiles that are placed later on.
+	for _, direction := range []byte{0, 1, 2} {
+		if g.config.isPinned(coord.Neighbor(direction)) && !g.isValidConnection(board, tile, coord, direction) {
+			return false
+		}
+	}
 	// Check boundaries.
 	for direction := range byte(6) {
 		if !board.ContainsCoord(coord.Neighbor(direction)) && tile.HasRoad(direction) {
@@ -121,5 +622,9 @@ func (g *Generator) isValidConnection(board *Board, tile Tile, coord Coord, dire
 		return !tile.HasRoad(direction)
 	}
 	neighborTile := board.Tile(neighborCoord)
-	return tile.HasRoad(direction) == neighborTile.HasRoad((direction+3)%6)
+	return tile.HasRoad(direction) == neighborTile.HasRoad(oppositeDirection(direction))
+}
+
+func isValidStartTile(tile Tile) bool {
+	return tile.Shape == ShapeKindRoadStraight && (tile.Rotation == 0 || tile.Rotation == 3)
 }
@@ -0,0 +1,198 @@
+package level_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+var generatorStrategies = map[string]func(level.GeneratorConfig) level.BoardGenerator{
+	"backtrack": func(config level.GeneratorConfig) level.BoardGenerator {
+		return level.NewGenerator(config)
+	},
+	"wfc": func(config level.GeneratorConfig) level.BoardGenerator {
+		return level.NewWFCGenerator(config)
+	},
+}
+
+func TestGeneratorRoadDensity(t *testing.T) {
+	for name, newGenerator := range generatorStrategies {
+		for _, density := range []float64{0.3, 0.6} {
+			for seed := uint64(1); seed <= 6; seed++ {
+				board, err := newGenerator(level.GeneratorConfig{
+					Seed:        seed,
+					Layout:      level.SquareLayout(7),
+					RoadDensity: density,
+				}).Generate(context.Background())
+				if err != nil {
+					t.Fatalf("%s, density %.1f, seed %d: %v", name, density, seed, err)
+				}
+				if actual := board.RoadDensity(); math.Abs(actual-density) > 0.1 {
+					t.Errorf("%s, density %.1f, seed %d: got density %.2f", name, density, seed, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestGeneratorShapeCaps(t *testing.T) {
+	for name, newGenerator := range generatorStrategies {
+		for _, maxSplits := range []int{0, 1, 2} {
+			for seed := uint64(1); seed <= 6; seed++ {
+				board, err := newGenerator(level.GeneratorConfig{
+					Seed:           seed,
+					Layout:         level.SquareLayout(7),
+					MaxShapeCounts: map[level.ShapeKind]int{level.ShapeKindRoadSplit: maxSplits},
+				}).Generate(context.Background())
+				if err != nil {
+					t.Fatalf("%s, max splits %d, seed %d: %v", name, maxSplits, seed, err)
+				}
+				if splits := board.ShapeCounts()[level.ShapeKindRoadSplit]; splits > maxSplits {
+					t.Errorf("%s, max splits %d, seed %d: got %d splits", name, maxSplits, seed, splits)
+				}
+			}
+		}
+	}
+}
+
+func TestGeneratorSupportsEveryBiome(t *testing.T) {
+	for biome := level.BiomeKindMeadow; biome.IsValid(); biome++ {
+		board, err := level.NewGenerator(level.GeneratorConfig{
+			Seed:   1,
+			Layout: level.SquareLayout(7),
+			Biome:  biome,
+		}).Generate(context.Background())
+		if err != nil {
+			t.Errorf("biome %d: %v", biome, err)
+			continue
+		}
+		if problems := board.Validate(); len(problems) > 0 {
+			t.Errorf("biome %d: %v", biome, problems)
+		}
+	}
+}
+
+func TestGeneratorRaisesHills(t *testing.T) {
+	for name, newGenerator := range generatorStrategies {
+		var slopes, raised int
+		for seed := range uint64(10) {
+			board, err := newGenerator(level.GeneratorConfig{
+				Seed:         seed,
+				Layout:       level.SquareLayout(9),
+				MaxElevation: 2,
+			}).Generate(context.Background())
+			if err != nil {
+				t.Fatalf("%s, seed %d: %v", name, seed, err)
+			}
+			if problems := board.Validate(); len(problems) > 0 {
+				t.Fatalf("%s, seed %d: %v", name, seed, problems)
+			}
+			for _, coord := range board.Coords() {
+				tile := board.Tile(coord)
+				if tile.Shape == level.ShapeKindRoadSlope {
+					slopes++
+				}
+				if tile.Elevation > 0 {
+					raised++
+				}
+			}
+		}
+		if slopes == 0 || raised == 0 {
+			t.Errorf("%s: expected hills, got %d slopes and %d raised tiles", name, slopes, raised)
+		}
+	}
+}
+
+func TestGeneratorRejectsInvalidLayouts(t *testing.T) {
+	layouts := []level.Layout{
+		level.SquareLayout(-3),
+		level.RectangleLayout(0, 5),
+		level.SquareLayout(level.MaxLayoutSize + 1),
+		{Kind: level.LayoutKindHexagon, Width: 5, Height: 5, Radius: 1},
+		{Kind: level.LayoutKindHexagon + 1, Width: 5, Height: 5},
+	}
+	for name, newGenerator := range generatorStrategies {
+		for _, layout := range layouts {
+			_, err := newGenerator(level.GeneratorConfig{
+				Seed:   1,
+				Layout: layout,
+			}).Generate(context.Background())
+			if !errors.Is(err, level.ErrUnsatisfiableConstraints) {
+				t.Errorf("%s, layout %+v: expected unsatisfiable constraints, got %v", name, layout, err)
+			}
+		}
+	}
+}
+
+func TestGeneratorRejectsShapesWithoutModels(t *testing.T) {
+	for _, shape := range []level.ShapeKind{
+		level.ShapeKindRoadCrossroads,
+		level.ShapeKindRoadJunction,
+		level.ShapeKindRoadDeadEnd,
+		level.ShapeKindRoadOverpass,
+	} {
+		for name, newGenerator := range generatorStrategies {
+			_, err := newGenerator(level.GeneratorConfig{
+				Seed:   1,
+				Layout: level.SquareLayout(7),
+				ShapeWeights: map[level.ShapeKind]float64{
+					level.ShapeKindTerrain:      1.0,
+					level.ShapeKindRoadStraight: 1.0,
+					shape:                       1.0,
+				},
+			}).Generate(context.Background())
+			if !errors.Is(err, level.ErrUnsatisfiableConstraints) {
+				t.Errorf("%s, shape %d: expected unsatisfiable constraints, got %v", name, shape, err)
+			}
+		}
+	}
+}
+
+func BenchmarkGeneratorSquare7(b *testing.B) {
+	benchmarkGenerator(b, generatorStrategies["backtrack"], level.SquareLayout(7))
+}
+
+func BenchmarkGeneratorSquare9(b *testing.B) {
+	benchmarkGenerator(b, generatorStrategies["backtrack"], level.SquareLayout(9))
+}
+
+func BenchmarkGeneratorHexagon4(b *testing.B) {
+	benchmarkGenerator(b, generatorStrategies["backtrack"], level.HexagonLayout(4))
+}
+
+func BenchmarkWFCSquare7(b *testing.B) {
+	benchmarkGenerator(b, generatorStrategies["wfc"], level.SquareLayout(7))
+}
+
+func BenchmarkWFCSquare9(b *testing.B) {
+	benchmarkGenerator(b, generatorStrategies["wfc"], level.SquareLayout(9))
+}
+
+// BenchmarkWFCSquare20 has no backtracking counterpart, since the
+// backtracking generator needs several seconds for most seeds at that size.
+func BenchmarkWFCSquare20(b *testing.B) {
+	benchmarkGenerator(b, generatorStrategies["wfc"], level.SquareLayout(20))
+}
+
+func BenchmarkWFCHexagon4(b *testing.B) {
+	benchmarkGenerator(b, generatorStrategies["wfc"], level.HexagonLayout(4))
+}
+
+// benchmarkGenerator cycles through a fixed set of seeds, so that the results
+// do not depend on how many iterations the benchmark runs.
+func benchmarkGenerator(b *testing.B, newGenerator func(level.GeneratorConfig) level.BoardGenerator, layout level.Layout) {
+	const seedCount = 20
+	b.ReportAllocs()
+	for i := range b.N {
+		_, err := newGenerator(level.GeneratorConfig{
+			Seed:   uint64(i%seedCount) + 1,
+			Layout: layout,
+		}).Generate(context.Background())
+		if err != nil {
+			b.Fatalf("seed %d: %v", i%seedCount+1, err)
+		}
+	}
+}
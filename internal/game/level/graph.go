@@ -0,0 +1,308 @@
+package level
+
+import "slices"
+
+const (
+	RoadNodeKindJunction RoadNodeKind = iota
+	RoadNodeKindDeadEnd
+	RoadNodeKindLoop
+)
+
+type RoadNodeKind byte
+
+type RoadNode struct {
+	Kind  RoadNodeKind
+	Coord Coord
+	Edges []int
+}
+
+type RoadEdge struct {
+	From          int
+	To            int
+	FromDirection byte
+	ToDirection   byte
+	Coords        []Coord
+}
+
+func (e RoadEdge) Length() int {
+	return len(e.Coords) - 1
+}
+
+type RoadGraph struct {
+	Nodes []RoadNode
+	Edges []RoadEdge
+}
+
+func (g *RoadGraph) NodeAt(coord Coord) (int, bool) {
+	for i, node := range g.Nodes {
+		if node.Coord == coord {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// RoadGraph builds a graph of the road network on the board. Nodes are
+// placed on junctions and dead ends and edges follow the road between them.
+// Loops that have no junctions are anchored at their first tile. Roads that
+// cross on an overpass continue through it without forming a node.
+func (b *Board) RoadGraph() *RoadGraph {
+	graph := &RoadGraph{}
+	nodeIndices := make(map[roadHalfEdge]int)
+	var nodeDirections [][]byte
+	addNode := func(kind RoadNodeKind, coord Coord, directions []byte) int {
+		nodeIndex := len(graph.Nodes)
+		for _, direction := range directions {
+			nodeIndices[roadHalfEdge{coord, direction}] = nodeIndex
+		}
+		graph.Nodes = append(graph.Nodes, RoadNode{
+			Kind:  kind,
+			Coord: coord,
+		})
+		nodeDirections = append(nodeDirections, directions)
+		return nodeIndex
+	}
+	for _, coord := range b.Coords() {
+		for _, group := range b.Tile(coord).RoadGroups() {
+			if connections := b.connectedDirections(coord, group); len(connections) != 2 {
+				addNode(roadNodeKind(len(connections)), coord, connections)
+			}
+		}
+	}
+
+	visited := make(map[roadHalfEdge]struct{})
+	traceNode := func(nodeIndex int) {
+		coord := graph.Nodes[nodeIndex].Coord
+		for _, direction := range nodeDirections[nodeIndex] {
+			if _, ok := visited[roadHalfEdge{coord, direction}]; ok {
+				continue
+			}
+			edge := b.traceRoadEdge(coord, direction, nodeIndices, visited)
+			edge.From = nodeIndex
+			edgeIndex := len(graph.Edges)
+			graph.Edges = append(graph.Edges, edge)
+			graph.Nodes[edge.From].Edges = append(graph.Nodes[edge.From].Edges, edgeIndex)
+			if edge.To != edge.From {
+				graph.Nodes[edge.To].Edges = append(graph.Nodes[edge.To].Edges, edgeIndex)
+			}
+		}
+	}
+	for nodeIndex := range graph.Nodes {
+		traceNode(nodeIndex)
+	}
+
+	// Whatever remains unvisited are closed loops without any junctions.
+	for _, coord := range b.Coords() {
+		for _, group := range b.Tile(coord).RoadGroups() {
+			connections := b.connectedDirections(coord, group)
+			if len(connections) != 2 {
+				continue
+			}
+			if _, ok := visited[roadHalfEdge{coord, connections[0]}]; ok {
+				continue
+			}
+			traceNode(addNode(RoadNodeKindLoop, coord, connections))
+		}
+	}
+	return graph
+}
+
+// RoadConnections returns the directions in which the road on the specified
+// tile continues into a neighboring tile that has a matching road.
+func (b *Board) RoadConnections(coord Coord) []byte {
+	var result []byte
+	for direction := range byte(6) {
+		if b.IsRoadConnected(coord, direction) {
+			result = append(result, direction)
+		}
+	}
+	return result
+}
+
+func (b *Board) IsRoadConnected(coord Coord, direction byte) bool {
+	if !b.Tile(coord).HasRoad(direction) {
+		return false
+	}
+	neighborCoord := coord.Neighbor(direction)
+	if !b.ContainsCoord(neighborCoord) {
+		return false
+	}
+	return b.Tile(neighborCoord).HasRoad(oppositeDirection(direction))
+}
+
+func (b *Board) connectedDirections(coord Coord, directions []byte) []byte {
+	var result []byte
+	for _, direction := range directions {
+		if b.IsRoadConnected(coord, direction) {
+			result = append(result, direction)
+		}
+	}
+	return result
+}
+
+func (b *Board) traceRoadEdge(coord Coord, direction byte, nodeIndices map[roadHalfEdge]int, visited map[roadHalfEdge]struct{}) RoadEdge {
+	edge := RoadEdge{
+		FromDirection: direction,
+		Coords:        []Coord{coord},
+	}
+	for {
+		visited[roadHalfEdge{coord, direction}] = struct{}{}
+		coord = coord.Neighbor(direction)
+		entry := oppositeDirection(direction)
+		visited[roadHalfEdge{coord, entry}] = struct{}{}
+		edge.Coords = append(edge.Coords, coord)
+		if nodeIndex, ok := nodeIndices[roadHalfEdge{coord, entry}]; ok {
+			edge.To = nodeIndex
+			edge.ToDirection = entry
+			return edge
+		}
+		for _, exit := range b.connectedDirections(coord, b.Tile(coord).RoadGroup(entry)) {
+			if exit != entry {
+				direction = exit
+				break
+			}
+		}
+	}
+}
+
+func roadNodeKind(connectionCount int) RoadNodeKind {
+	if connectionCount <= 1 {
+		return RoadNodeKindDeadEnd
+	}
+	return RoadNodeKindJunction
+}
+
+type roadHalfEdge struct {
+	coord     Coord
+	direction byte
+}
+
+// RoadComponents groups the road tiles on the board into sets that are
+// connected by road. Components are ordered by their first tile in
+// row-major order. A tile with an overpass can be part of two components.
+func (b *Board) RoadComponents() [][]Coord {
+	var result [][]Coord
+	for _, component := range b.roadComponents() {
+		var coords []Coord
+		for _, road := range component {
+			if !slices.Contains(coords, road.coord) {
+				coords = append(coords, road.coord)
+			}
+		}
+		result = append(result, coords)
+	}
+	return result
+}
+
+// PruneRoadIslands turns all roads that cannot be reached from the start
+// tile into terrain and returns the number of changed tiles.
+func (b *Board) PruneRoadIslands(start Coord) int {
+	keptDirections := make(map[Coord][]byte)
+	for _, component := range b.roadComponents() {
+		if !slices.ContainsFunc(component, func(road roadHalfEdge) bool {
+			return road.coord == start
+		}) {
+			continue
+		}
+		for _, road := range component {
+			keptDirections[road.coord] = append(keptDirections[road.coord], b.Tile(road.coord).RoadGroup(road.direction)...)
+		}
+	}
+
+	var count int
+	for _, coord := range b.Coords() {
+		tile := b.Tile(coord)
+		if !tile.HasAnyRoad() {
+			continue
+		}
+		directions := keptDirections[coord]
+		if len(directions) == len(slices.Concat(tile.RoadGroups()...)) {
+			continue
+		}
+		b.SetTile(coord, roadTile(tile, directions))
+		count++
+	}
+	return count
+}
+
+// roadComponents returns the connected road networks on the board, where
+// each road group of a tile is identified by its first direction.
+func (b *Board) roadComponents() [][]roadHalfEdge {
+	var result [][]roadHalfEdge
+	visited := make(map[roadHalfEdge]struct{})
+	for _, coord := range b.Coords() {
+		for _, group := range b.Tile(coord).RoadGroups() {
+			road := roadHalfEdge{coord, group[0]}
+			if _, ok := visited[road]; ok {
+				continue
+			}
+			visited[road] = struct{}{}
+			component := []roadHalfEdge{road}
+			for i := 0; i < len(component); i++ {
+				current := component[i]
+				currentGroup := b.Tile(current.coord).RoadGroup(current.direction)
+				for _, direction := range b.connectedDirections(current.coord, currentGroup) {
+					neighborCoord := current.coord.Neighbor(direction)
+					neighborGroup := b.Tile(neighborCoord).RoadGroup(oppositeDirection(direction))
+					neighborRoad := roadHalfEdge{neighborCoord, neighborGroup[0]}
+					if _, ok := visited[neighborRoad]; !ok {
+						visited[neighborRoad] = struct{}{}
+						component = append(component, neighborRoad)
+					}
+				}
+			}
+			result = append(result, component)
+		}
+	}
+	return result
+}
+
+// roadTile returns a tile with the same ground and road as the specified
+// one, whose roads lead exactly in the specified directions. Terrain is
+// returned when there are no directions or no shape with a model for the
+// ground and road matches them, so the result can always be played. The
+// elevation follows the remaining roads, so that a cut slope keeps the
+// height of its remaining side.
+func roadTile(tile Tile, directions []byte) Tile {
+	if len(directions) > 0 {
+		tile.Elevation = byte(tile.EdgeElevation(directions[0]))
+	}
+	for _, shape := range []ShapeKind{
+		ShapeKindRoadStraight,
+		ShapeKindRoadCornerSmooth,
+		ShapeKindRoadCornerSharp,
+		ShapeKindRoadSplit,
+		ShapeKindRoadCrossroads,
+		ShapeKindRoadJunction,
+		ShapeKindRoadDeadEnd,
+	} {
+		if _, ok := (Tile{Shape: shape, Ground: tile.Ground, Road: tile.Road}).nodeName(); !ok {
+			continue
+		}
+		for rotation := range byte(6) {
+			candidate := Tile{
+				Shape:     shape,
+				Ground:    tile.Ground,
+				Road:      tile.Road,
+				Rotation:  rotation,
+				Elevation: tile.Elevation,
+			}
+			matches := true
+			for direction := range byte(6) {
+				if candidate.HasRoad(direction) != slices.Contains(directions, direction) {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				return candidate
+			}
+		}
+	}
+	return Tile{
+		Shape:     ShapeKindTerrain,
+		Ground:    tile.Ground,
+		Road:      tile.Road,
+		Elevation: tile.Elevation,
+	}
+}
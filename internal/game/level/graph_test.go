@@ -0,0 +1,203 @@
+package level_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestBoardRoadGraphOfLoop(t *testing.T) {
+	graph := ovalBoard(t).RoadGraph()
+	if len(graph.Nodes) != 1 || graph.Nodes[0].Kind != level.RoadNodeKindLoop || graph.Nodes[0].Coord != level.C(0, 0) {
+		t.Fatalf("expected a single loop node at (0,0), got %+v", graph.Nodes)
+	}
+	if len(graph.Edges) != 1 {
+		t.Fatalf("expected a single edge, got %d", len(graph.Edges))
+	}
+	edge := graph.Edges[0]
+	if edge.From != 0 || edge.To != 0 || edge.Length() != 6 || edge.Coords[0] != edge.Coords[len(edge.Coords)-1] {
+		t.Errorf("expected an edge of length 6 from the node back to itself, got %+v", edge)
+	}
+}
+
+func TestBoardRoadGraphOfJunctions(t *testing.T) {
+	board, err := level.ParseBoardText(`
+		layout square 7
+		biome meadow
+		K2  C0  C1  C0  .0  C1  C0
+		  S2  K4  K2  K5  K3  K0  C5
+		K2  K5  C1  K5  .3  K3  Y2
+		  Y1  C4  C1  S0  K0  K1  C5
+		K3  C0  C2  C1  Y0  C2  K4
+		  .1  S5  K4  K2  K5  Y3  K0
+		.2  .4  C3  S3  K5  K3  C4
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph := board.RoadGraph()
+	degrees := make([]int, len(graph.Nodes))
+	for _, edge := range graph.Edges {
+		degrees[edge.From]++
+		degrees[edge.To]++
+	}
+	var junctions []level.Coord
+	for i, node := range graph.Nodes {
+		// A loop that returns to the same junction is listed once.
+		if node.Kind != level.RoadNodeKindJunction || degrees[i] != 3 {
+			t.Errorf("expected junctions with three road ends, got %+v", node)
+		}
+		junctions = append(junctions, node.Coord)
+	}
+	expected := []level.Coord{level.C(6, 2), level.C(0, 3), level.C(4, 4), level.C(5, 5)}
+	if len(junctions) != len(expected) || !containsAll(junctions, expected) {
+		t.Errorf("expected junctions at %v, got %v", expected, junctions)
+	}
+	if len(graph.Edges) != 6 {
+		t.Errorf("expected 6 edges, got %d", len(graph.Edges))
+	}
+	var roadTiles int
+	for _, coord := range board.Coords() {
+		if board.Tile(coord).HasAnyRoad() {
+			roadTiles++
+		}
+	}
+	var edgeTiles int
+	for _, edge := range graph.Edges {
+		if edge.Coords[0] != graph.Nodes[edge.From].Coord || edge.Coords[len(edge.Coords)-1] != graph.Nodes[edge.To].Coord {
+			t.Errorf("edge %+v does not connect its nodes", edge)
+		}
+		edgeTiles += edge.Length() - 1
+	}
+	if edgeTiles+len(junctions) != roadTiles {
+		t.Errorf("edges pass through %d tiles between %d junctions, but there are %d road tiles", edgeTiles, len(junctions), roadTiles)
+	}
+}
+
+func TestBoardRoadComponentsAndPruning(t *testing.T) {
+	board, err := level.ParseBoardText(`
+		layout rectangle 6 3
+		biome meadow
+		K2  S3  C0  K2  S3  C0
+		  C3  S3  K5  C3  S3  K5
+		.1  .2  .2  .1  .2  .2
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	components := board.RoadComponents()
+	if len(components) != 2 || len(components[0]) != 6 || len(components[1]) != 6 {
+		t.Fatalf("expected two components of 6 tiles, got %v", components)
+	}
+	if !slices.Contains(components[0], level.C(0, 0)) || !slices.Contains(components[1], level.C(3, 0)) {
+		t.Errorf("expected the components in row-major order, got %v", components)
+	}
+
+	if count := board.PruneRoadIslands(level.C(1, 1)); count != 6 {
+		t.Errorf("expected 6 pruned tiles, got %d", count)
+	}
+	for _, coord := range components[1] {
+		if board.Tile(coord).HasAnyRoad() {
+			t.Errorf("road at %s was not pruned", coord)
+		}
+	}
+	if components := board.RoadComponents(); len(components) != 1 || len(components[0]) != 6 {
+		t.Errorf("expected a single component of 6 tiles after pruning, got %v", components)
+	}
+	if count := board.PruneRoadIslands(level.C(1, 1)); count != 0 {
+		t.Errorf("expected nothing to prune the second time, got %d tiles", count)
+	}
+}
+
+// overpassBoard returns a board whose center is an overpass, where one road
+// is a spur between two dead ends and the other one is part of a loop. The
+// overpass has no model, so the board cannot be written as text.
+func overpassBoard(t *testing.T) *level.Board {
+	t.Helper()
+	board := level.NewBoard(level.SquareLayout(7))
+	for _, coord := range board.Coords() {
+		board.SetTile(coord, level.Tile{Shape: level.ShapeKindTerrain, Ground: level.GroundKindGrass, Road: level.RoadKindDirt})
+	}
+	board.SetTile(level.C(3, 3), level.Tile{
+		Shape:  level.ShapeKindRoadOverpass,
+		Ground: level.GroundKindGrass,
+		Road:   level.RoadKindDirt,
+	})
+	for coord, directions := range map[level.Coord][]byte{
+		level.C(4, 4): {4},
+		level.C(3, 2): {1},
+		level.C(4, 3): {3, 5},
+		level.C(5, 2): {2, 3},
+		level.C(4, 2): {0, 4},
+		level.C(3, 1): {1, 3},
+		level.C(2, 1): {0, 2},
+		level.C(2, 2): {1, 5},
+		level.C(2, 3): {0, 4},
+	} {
+		board.SetTile(coord, roadTile(t, directions...))
+	}
+	return board
+}
+
+func TestBoardRoadGraphThroughOverpass(t *testing.T) {
+	graph := overpassBoard(t).RoadGraph()
+	var deadEnds, loops int
+	for _, node := range graph.Nodes {
+		switch node.Kind {
+		case level.RoadNodeKindDeadEnd:
+			deadEnds++
+		case level.RoadNodeKindLoop:
+			loops++
+		default:
+			t.Errorf("unexpected node %+v", node)
+		}
+		if node.Coord == level.C(3, 3) {
+			t.Errorf("overpass should not be a node")
+		}
+	}
+	if deadEnds != 2 || loops != 1 {
+		t.Errorf("expected 2 dead ends and 1 loop, got %d and %d", deadEnds, loops)
+	}
+	var lengths []int
+	for _, edge := range graph.Edges {
+		if !slices.Contains(edge.Coords, level.C(3, 3)) {
+			t.Errorf("edge %+v does not pass through the overpass", edge)
+		}
+		lengths = append(lengths, edge.Length())
+	}
+	slices.Sort(lengths)
+	if !slices.Equal(lengths, []int{2, 8}) {
+		t.Errorf("expected edges of length 2 and 8, got %v", lengths)
+	}
+}
+
+func TestBoardRoadComponentsThroughOverpass(t *testing.T) {
+	board := overpassBoard(t)
+	components := board.RoadComponents()
+	if len(components) != 2 {
+		t.Fatalf("expected two components, got %v", components)
+	}
+	for _, component := range components {
+		if !slices.Contains(component, level.C(3, 3)) {
+			t.Errorf("component %v does not contain the overpass", component)
+		}
+	}
+
+	// Pruning from the loop removes the spur and leaves a straight road on
+	// the overpass tile.
+	if count := board.PruneRoadIslands(level.C(2, 1)); count != 3 {
+		t.Errorf("expected 3 changed tiles, got %d", count)
+	}
+	for _, coord := range []level.Coord{level.C(3, 2), level.C(4, 4)} {
+		if board.Tile(coord).HasAnyRoad() {
+			t.Errorf("spur at %s was not pruned", coord)
+		}
+	}
+	if tile := board.Tile(level.C(3, 3)); tile.Shape != level.ShapeKindRoadStraight || !tile.HasRoad(0) || !tile.HasRoad(3) {
+		t.Errorf("expected a straight road along the loop on the overpass tile, got %+v", tile)
+	}
+	if components := board.RoadComponents(); len(components) != 1 || len(components[0]) != 8 {
+		t.Errorf("expected the loop of 8 tiles after pruning, got %v", components)
+	}
+}
@@ -0,0 +1,189 @@
+package level
+
+import "math"
+
+var axialDirections = [6]Axial{
+	{Q: 1, R: 0},
+	{Q: 0, R: 1},
+	{Q: -1, R: 1},
+	{Q: -1, R: 0},
+	{Q: 0, R: -1},
+	{Q: 1, R: -1},
+}
+
+// AxialDirection returns the axial offset that corresponds to moving one
+// tile in the specified direction. It matches Coord.Neighbor.
+func AxialDirection(direction byte) Axial {
+	return axialDirections[direction%6]
+}
+
+// Axial represents a hex coordinate in the axial coordinate system, where
+// the Q axis follows direction 0 and the R axis follows direction 1.
+type Axial struct {
+	Q int
+	R int
+}
+
+func (a Axial) Add(other Axial) Axial {
+	return Axial{Q: a.Q + other.Q, R: a.R + other.R}
+}
+
+func (a Axial) Sub(other Axial) Axial {
+	return Axial{Q: a.Q - other.Q, R: a.R - other.R}
+}
+
+func (a Axial) Scale(amount int) Axial {
+	return Axial{Q: a.Q * amount, R: a.R * amount}
+}
+
+func (a Axial) Length() int {
+	return (abs(a.Q) + abs(a.R) + abs(a.Q+a.R)) / 2
+}
+
+func (a Axial) Cube() Cube {
+	return Cube{Q: a.Q, R: a.R, S: -a.Q - a.R}
+}
+
+func (a Axial) Coord() Coord {
+	return C(a.Q+(a.R-(a.R&1))/2, a.R)
+}
+
+// Cube represents a hex coordinate in the cube coordinate system. All valid
+// cube coordinates satisfy Q+R+S == 0.
+type Cube struct {
+	Q int
+	R int
+	S int
+}
+
+func (c Cube) Axial() Axial {
+	return Axial{Q: c.Q, R: c.R}
+}
+
+func (c Cube) Coord() Coord {
+	return c.Axial().Coord()
+}
+
+// Rotate rotates the cube coordinate around the origin by the specified
+// number of 60 degree steps. A positive step moves direction N onto
+// direction N+1.
+func (c Cube) Rotate(steps int) Cube {
+	for range ((steps % 6) + 6) % 6 {
+		c = Cube{Q: -c.R, R: -c.S, S: -c.Q}
+	}
+	return c
+}
+
+func (c Coord) Axial() Axial {
+	return Axial{Q: c.X - (c.Y-(c.Y&1))/2, R: c.Y}
+}
+
+func (c Coord) Cube() Cube {
+	return c.Axial().Cube()
+}
+
+func (c Coord) Distance(other Coord) int {
+	return c.Axial().Sub(other.Axial()).Length()
+}
+
+// Ring returns all coordinates that are exactly radius steps away, starting
+// from the one in direction 4 and continuing in increasing direction order.
+func (c Coord) Ring(radius int) []Coord {
+	if radius <= 0 {
+		return []Coord{c}
+	}
+	result := make([]Coord, 0, 6*radius)
+	current := c.Axial().Add(AxialDirection(4).Scale(radius))
+	for direction := range byte(6) {
+		for range radius {
+			result = append(result, current.Coord())
+			current = current.Add(AxialDirection(direction))
+		}
+	}
+	return result
+}
+
+// Spiral returns the coordinate followed by all rings up to the specified
+// radius.
+func (c Coord) Spiral(radius int) []Coord {
+	result := []Coord{c}
+	for ring := 1; ring <= radius; ring++ {
+		result = append(result, c.Ring(ring)...)
+	}
+	return result
+}
+
+// Range returns all coordinates that are at most radius steps away, in
+// row-major order.
+func (c Coord) Range(radius int) []Coord {
+	if radius < 0 {
+		return nil
+	}
+	center := c.Axial()
+	var result []Coord
+	for dr := -radius; dr <= radius; dr++ {
+		for dq := max(-radius, -dr-radius); dq <= min(radius, -dr+radius); dq++ {
+			result = append(result, center.Add(Axial{Q: dq, R: dr}).Coord())
+		}
+	}
+	return result
+}
+
+// Line returns the coordinates of the tiles that a straight line between the
+// two coordinates passes through, including both ends.
+func (c Coord) Line(to Coord) []Coord {
+	distance := c.Distance(to)
+	if distance == 0 {
+		return []Coord{c}
+	}
+	// The nudge keeps points that fall exactly on an edge between two tiles
+	// from being rounded inconsistently.
+	const nudge = 1e-6
+	from, target := c.Cube(), to.Cube()
+	result := make([]Coord, 0, distance+1)
+	for i := range distance + 1 {
+		t := float64(i) / float64(distance)
+		result = append(result, roundCube(
+			lerp(float64(from.Q)+nudge, float64(target.Q)+nudge, t),
+			lerp(float64(from.R)+nudge, float64(target.R)+nudge, t),
+			lerp(float64(from.S)-2*nudge, float64(target.S)-2*nudge, t),
+		).Coord())
+	}
+	return result
+}
+
+// Rotate rotates the coordinate around the pivot by the specified number of
+// 60 degree steps. A positive step moves direction N onto direction N+1.
+func (c Coord) Rotate(pivot Coord, steps int) Coord {
+	offset := c.Axial().Sub(pivot.Axial()).Cube().Rotate(steps)
+	return pivot.Axial().Add(offset.Axial()).Coord()
+}
+
+// DirectionTo returns the direction in which the specified neighbor is
+// located. The second result is false if the coordinate is not a neighbor.
+func (c Coord) DirectionTo(neighbor Coord) (byte, bool) {
+	for direction, neighborCoord := range c.Neighbors() {
+		if neighborCoord == neighbor {
+			return byte(direction), true
+		}
+	}
+	return 0, false
+}
+
+func roundCube(q, r, s float64) Cube {
+	roundQ, roundR, roundS := math.Round(q), math.Round(r), math.Round(s)
+	diffQ, diffR, diffS := math.Abs(roundQ-q), math.Abs(roundR-r), math.Abs(roundS-s)
+	switch {
+	case diffQ > diffR && diffQ > diffS:
+		roundQ = -roundR - roundS
+	case diffR > diffS:
+		roundR = -roundQ - roundS
+	default:
+		roundS = -roundQ - roundR
+	}
+	return Cube{Q: int(roundQ), R: int(roundR), S: int(roundS)}
+}
+
+func lerp(from, to, t float64) float64 {
+	return from + (to-from)*t
+}
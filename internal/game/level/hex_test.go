@@ -0,0 +1,246 @@
+package level_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestCoordNeighbor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		coord    level.Coord
+		expected [6]level.Coord
+	}{
+		{
+			name:  "even row",
+			coord: level.C(2, 2),
+			expected: [6]level.Coord{
+				level.C(3, 2), level.C(2, 3), level.C(1, 3),
+				level.C(1, 2), level.C(1, 1), level.C(2, 1),
+			},
+		},
+		{
+			name:  "odd row",
+			coord: level.C(2, 3),
+			expected: [6]level.Coord{
+				level.C(3, 3), level.C(3, 4), level.C(2, 4),
+				level.C(1, 3), level.C(2, 2), level.C(3, 2),
+			},
+		},
+		{
+			name:  "negative odd row",
+			coord: level.C(0, -1),
+			expected: [6]level.Coord{
+				level.C(1, -1), level.C(1, 0), level.C(0, 0),
+				level.C(-1, -1), level.C(0, -2), level.C(1, -2),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		for direction := range byte(6) {
+			neighbor := tc.coord.Neighbor(direction)
+			if neighbor != tc.expected[direction] {
+				t.Errorf("%s, direction %d: expected %s, got %s", tc.name, direction, tc.expected[direction], neighbor)
+			}
+			if axialNeighbor := tc.coord.Axial().Add(level.AxialDirection(direction)).Coord(); axialNeighbor != neighbor {
+				t.Errorf("%s, direction %d: axial direction leads to %s instead of %s", tc.name, direction, axialNeighbor, neighbor)
+			}
+			if actual, ok := tc.coord.DirectionTo(neighbor); !ok || actual != direction {
+				t.Errorf("%s, direction %d: got direction %d (%t) back", tc.name, direction, actual, ok)
+			}
+			if back := neighbor.Neighbor(direction + 3); back != tc.coord {
+				t.Errorf("%s, direction %d: opposite direction leads to %s", tc.name, direction, back)
+			}
+		}
+	}
+}
+
+func TestCoordConversionRoundTrip(t *testing.T) {
+	for y := -5; y <= 5; y++ {
+		for x := -5; x <= 5; x++ {
+			coord := level.C(x, y)
+			if actual := coord.Axial().Coord(); actual != coord {
+				t.Errorf("axial round trip of %s gave %s", coord, actual)
+			}
+			cube := coord.Cube()
+			if cube.Q+cube.R+cube.S != 0 {
+				t.Errorf("cube coordinate %+v of %s is not valid", cube, coord)
+			}
+			if actual := cube.Coord(); actual != coord {
+				t.Errorf("cube round trip of %s gave %s", coord, actual)
+			}
+			if actual := cube.Axial(); actual != coord.Axial() {
+				t.Errorf("cube of %s converts to axial %+v instead of %+v", coord, actual, coord.Axial())
+			}
+		}
+	}
+}
+
+func TestCoordDistance(t *testing.T) {
+	testCases := []struct {
+		from     level.Coord
+		to       level.Coord
+		expected int
+	}{
+		{from: level.C(0, 0), to: level.C(0, 0), expected: 0},
+		{from: level.C(2, 2), to: level.C(3, 2), expected: 1},
+		{from: level.C(2, 3), to: level.C(3, 4), expected: 1},
+		{from: level.C(0, 0), to: level.C(3, 0), expected: 3},
+		{from: level.C(0, 0), to: level.C(1, 2), expected: 2},
+		{from: level.C(0, 0), to: level.C(0, 3), expected: 3},
+		{from: level.C(0, 0), to: level.C(3, 3), expected: 5},
+		{from: level.C(-2, -1), to: level.C(2, 4), expected: 6},
+	}
+	for _, tc := range testCases {
+		if actual := tc.from.Distance(tc.to); actual != tc.expected {
+			t.Errorf("distance from %s to %s: expected %d, got %d", tc.from, tc.to, tc.expected, actual)
+		}
+		if actual := tc.to.Distance(tc.from); actual != tc.expected {
+			t.Errorf("distance from %s to %s: expected %d, got %d", tc.to, tc.from, tc.expected, actual)
+		}
+	}
+}
+
+func TestCoordRingAndSpiral(t *testing.T) {
+	for _, center := range []level.Coord{level.C(3, 3), level.C(4, 4)} {
+		for radius := 0; radius <= 4; radius++ {
+			ring := center.Ring(radius)
+			expectedRing := max(1, 6*radius)
+			if len(ring) != expectedRing {
+				t.Errorf("ring %d around %s: expected %d coordinates, got %d", radius, center, expectedRing, len(ring))
+			}
+			for _, coord := range ring {
+				if distance := center.Distance(coord); distance != radius {
+					t.Errorf("ring %d around %s: %s is at distance %d", radius, center, coord, distance)
+				}
+			}
+			if !isUnique(ring) {
+				t.Errorf("ring %d around %s has duplicate coordinates", radius, center)
+			}
+
+			spiral := center.Spiral(radius)
+			expectedSpiral := 1 + 3*radius*(radius+1)
+			if len(spiral) != expectedSpiral {
+				t.Errorf("spiral %d around %s: expected %d coordinates, got %d", radius, center, expectedSpiral, len(spiral))
+			}
+			if !isUnique(spiral) {
+				t.Errorf("spiral %d around %s has duplicate coordinates", radius, center)
+			}
+			inRange := center.Range(radius)
+			if len(inRange) != len(spiral) || !containsAll(inRange, spiral) {
+				t.Errorf("spiral %d around %s does not cover the same coordinates as the range", radius, center)
+			}
+		}
+	}
+}
+
+func TestCoordLine(t *testing.T) {
+	testCases := []struct {
+		from     level.Coord
+		to       level.Coord
+		expected []level.Coord
+	}{
+		{
+			from:     level.C(2, 2),
+			to:       level.C(2, 2),
+			expected: []level.Coord{level.C(2, 2)},
+		},
+		{
+			from:     level.C(0, 0),
+			to:       level.C(3, 0),
+			expected: []level.Coord{level.C(0, 0), level.C(1, 0), level.C(2, 0), level.C(3, 0)},
+		},
+		{
+			from:     level.C(1, 1),
+			to:       level.C(3, 5),
+			expected: []level.Coord{level.C(1, 1), level.C(2, 2), level.C(2, 3), level.C(3, 4), level.C(3, 5)},
+		},
+		{
+			// The midpoint lies on the edge between two tiles.
+			from:     level.C(0, 0),
+			to:       level.C(0, 2),
+			expected: []level.Coord{level.C(0, 0), level.C(0, 1), level.C(0, 2)},
+		},
+	}
+	for _, tc := range testCases {
+		if actual := tc.from.Line(tc.to); !slices.Equal(actual, tc.expected) {
+			t.Errorf("line from %s to %s: expected %v, got %v", tc.from, tc.to, tc.expected, actual)
+		}
+	}
+
+	from := level.C(1, 1)
+	for y := -4; y <= 6; y++ {
+		for x := -4; x <= 6; x++ {
+			to := level.C(x, y)
+			line := from.Line(to)
+			if len(line) != from.Distance(to)+1 || line[0] != from || line[len(line)-1] != to {
+				t.Errorf("line from %s to %s: got %v", from, to, line)
+				continue
+			}
+			for i := 1; i < len(line); i++ {
+				if _, ok := line[i-1].DirectionTo(line[i]); !ok {
+					t.Errorf("line from %s to %s: %s does not follow %s", from, to, line[i], line[i-1])
+				}
+			}
+		}
+	}
+}
+
+func TestCoordRotate(t *testing.T) {
+	if actual := level.C(3, 2).Rotate(level.C(2, 2), 1); actual != level.C(2, 3) {
+		t.Errorf("expected rotation onto (2,3), got %s", actual)
+	}
+	if actual := level.C(4, 2).Rotate(level.C(2, 2), 3); actual != level.C(0, 2) {
+		t.Errorf("expected rotation onto (0,2), got %s", actual)
+	}
+
+	for _, pivot := range []level.Coord{level.C(2, 2), level.C(2, 3)} {
+		for direction := range byte(6) {
+			for steps := -7; steps <= 7; steps++ {
+				expected := pivot.Neighbor(byte(((int(direction)+steps)%6 + 6) % 6))
+				if actual := pivot.Neighbor(direction).Rotate(pivot, steps); actual != expected {
+					t.Errorf("pivot %s, direction %d, steps %d: expected %s, got %s", pivot, direction, steps, expected, actual)
+				}
+			}
+		}
+		for y := -3; y <= 5; y++ {
+			for x := -3; x <= 5; x++ {
+				coord := level.C(x, y)
+				for steps := range 6 {
+					rotated := coord.Rotate(pivot, steps)
+					if rotated.Distance(pivot) != coord.Distance(pivot) {
+						t.Errorf("pivot %s: rotating %s by %d steps changed its distance", pivot, coord, steps)
+					}
+					if back := rotated.Rotate(pivot, -steps); back != coord {
+						t.Errorf("pivot %s: rotating %s by %d steps and back gave %s", pivot, coord, steps, back)
+					}
+				}
+				if actual := coord.Rotate(pivot, 6); actual != coord {
+					t.Errorf("pivot %s: full rotation of %s gave %s", pivot, coord, actual)
+				}
+			}
+		}
+	}
+}
+
+func isUnique(coords []level.Coord) bool {
+	seen := make(map[level.Coord]struct{}, len(coords))
+	for _, coord := range coords {
+		if _, ok := seen[coord]; ok {
+			return false
+		}
+		seen[coord] = struct{}{}
+	}
+	return true
+}
+
+func containsAll(coords, others []level.Coord) bool {
+	for _, other := range others {
+		if !slices.Contains(coords, other) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package level
+
+const (
+	LayoutKindRectangle LayoutKind = iota
+	LayoutKindHexagon
+)
+
+// MaxLayoutSize is the largest width and height that a board can have.
+const MaxLayoutSize = 1024
+
+type LayoutKind byte
+
+func (k LayoutKind) IsValid() bool {
+	return k <= LayoutKindHexagon
+}
+
+func SquareLayout(size int) Layout {
+	return RectangleLayout(size, size)
+}
+
+func RectangleLayout(width, height int) Layout {
+	return Layout{
+		Kind:   LayoutKindRectangle,
+		Width:  width,
+		Height: height,
+	}
+}
+
+// HexagonLayout returns the layout of a round board that contains all tiles
+// that are at most radius steps away from the center tile.
+func HexagonLayout(radius int) Layout {
+	return Layout{
+		Kind:   LayoutKindHexagon,
+		Width:  2*radius + 1,
+		Height: 2*radius + 1,
+		Radius: radius,
+	}
+}
+
+// Layout describes the shape of a board. The Width and Height always specify
+// the bounding rectangle, even for hexagon layouts.
+type Layout struct {
+	Kind   LayoutKind
+	Width  int
+	Height int
+	Radius int
+}
+
+func (l Layout) Center() Coord {
+	return C(l.Width/2, l.Height/2)
+}
+
+func (l Layout) ContainsCoord(coord Coord) bool {
+	if coord.X < 0 || coord.X >= l.Width || coord.Y < 0 || coord.Y >= l.Height {
+		return false
+	}
+	switch l.Kind {
+	case LayoutKindHexagon:
+		return coord.Distance(l.Center()) <= l.Radius
+	default:
+		return true
+	}
+}
+
+func (l Layout) isConsistent() bool {
+	if !l.Kind.IsValid() || l.Width < 1 || l.Width > MaxLayoutSize || l.Height < 1 || l.Height > MaxLayoutSize {
+		return false
+	}
+	switch l.Kind {
+	case LayoutKindHexagon:
+		return l.Radius >= 0 && l.Width == 2*l.Radius+1 && l.Height == 2*l.Radius+1
+	default:
+		return l.Radius == 0
+	}
+}
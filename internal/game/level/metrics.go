@@ -0,0 +1,101 @@
+package level
+
+// TrackMetrics describes the lap on a board, which is the route that leaves
+// the start tile and takes the straightest branch at every split until it
+// returns to the start tile. All values except RoadLength and DeadEnds are
+// zero when there is no such lap.
+type TrackMetrics struct {
+	// LapLength is the length in meters of the lap.
+	LapLength float64
+
+	// RoadLength is the length in meters of all roads on the board.
+	RoadLength float64
+
+	// LongestStraight is the length in meters of the longest stretch of the
+	// lap that continues in the same direction.
+	LongestStraight float64
+
+	// The following fields count the tiles of each kind along the lap,
+	// where tiles that the lap passes twice are counted twice.
+	SmoothCorners int
+	SharpCorners  int
+
+	// Splits counts the tiles where the road branches in two, which are
+	// splits and junctions.
+	Splits int
+
+	// Intersections counts the crossroads.
+	Intersections int
+
+	Slopes int
+
+	// DeadEnds counts the dead ends anywhere on the board, since the lap
+	// cannot pass through one.
+	DeadEnds int
+
+	// Difficulty is a score between 0 and 10 that grows with the number of
+	// corners, splits, intersections and slopes relative to the lap length.
+	// A lap made only of sharp corners scores 10.
+	Difficulty float64
+}
+
+// Metrics measures the track on the board.
+func (b *Board) Metrics() TrackMetrics {
+	var result TrackMetrics
+	var roadTiles int
+	for _, coord := range b.Coords() {
+		tile := b.Tile(coord)
+		if !tile.HasAnyRoad() {
+			continue
+		}
+		roadTiles++
+		if tile.Shape == ShapeKindRoadDeadEnd {
+			result.DeadEnds++
+		}
+	}
+	result.RoadLength = float64(roadTiles) * TileSpacing
+
+	route, err := b.Route(BranchRuleStraight)
+	if err != nil {
+		return result
+	}
+	result.LapLength = float64(len(route)) * TileSpacing
+	for _, step := range route {
+		switch b.Tile(step.Coord).Shape {
+		case ShapeKindRoadCornerSmooth:
+			result.SmoothCorners++
+		case ShapeKindRoadCornerSharp:
+			result.SharpCorners++
+		case ShapeKindRoadSplit, ShapeKindRoadJunction:
+			result.Splits++
+		case ShapeKindRoadCrossroads:
+			result.Intersections++
+		case ShapeKindRoadSlope:
+			result.Slopes++
+		}
+	}
+	result.LongestStraight = float64(longestStraight(route)) * TileSpacing
+
+	score := 0.5*float64(result.SmoothCorners) +
+		float64(result.SharpCorners) +
+		0.25*float64(result.Splits+result.Intersections+result.Slopes)
+	result.Difficulty = min(10.0, 10.0*score/float64(len(route)))
+	return result
+}
+
+// longestStraight returns the number of steps in the longest run of route
+// steps that pass straight through their tiles. Since the route is a loop,
+// a run can continue from its last step to its first one.
+func longestStraight(route []RouteStep) int {
+	var longest, current int
+	for i := range 2 * len(route) {
+		step := route[i%len(route)]
+		if step.Exit != oppositeDirection(step.Entry) {
+			current = 0
+			continue
+		}
+		current++
+		longest = max(longest, min(current, len(route)))
+	}
+	return longest
+}
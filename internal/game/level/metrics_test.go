@@ -0,0 +1,226 @@
+package level_test
+
+import (
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestBoardMetricsLapLength(t *testing.T) {
+	testCases := []struct {
+		name     string
+		board    string
+		expected int
+	}{
+		{
+			name: "figure with a short loop",
+			// The start tile also lies on a loop of 7 tiles, but the route
+			// continues straight on through the splits.
+			board: `
+				layout square 7
+				biome meadow
+				K2  C0  C1  C0  .0  C1  C0
+				  S2  K4  K2  K5  K3  K0  C5
+				K2  K5  C1  K5  .3  K3  Y2
+				  Y1  C4  C1  S0  K0  K1  C5
+				K3  C0  C2  C1  Y0  C2  K4
+				  .1  S5  K4  K2  K5  Y3  K0
+				.2  .4  C3  S3  K5  K3  C4
+			`,
+			expected: 39,
+		},
+		{
+			name: "oval",
+			board: `
+				layout square 3
+				biome meadow
+				K2  S3  C0
+				  C3  S3  K5
+				.1  .2  .2
+			`,
+			expected: 6,
+		},
+	}
+	for _, tc := range testCases {
+		board, err := level.ParseBoardText(tc.board)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		expected := float64(tc.expected) * level.TileSpacing
+		if actual := board.Metrics().LapLength; actual != expected {
+			t.Errorf("%s: expected lap length %.0f, got %.0f", tc.name, expected, actual)
+		}
+	}
+}
+
+func TestBoardMetricsShapeCounts(t *testing.T) {
+	oval := func(t *testing.T) *level.Board {
+		board, err := level.ParseBoardText(`
+			layout square 3
+			biome meadow
+			K2  S3  C0
+			  C3  S3  K5
+			.1  .2  .2
+		`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return board
+	}
+	withShape := func(board *level.Board, coord level.Coord, shape level.ShapeKind) *level.Board {
+		if !replaceTile(board, coord, shape) {
+			t.Fatalf("shape %d cannot replace the tile at %s", shape, coord)
+		}
+		return board
+	}
+
+	testCases := []struct {
+		name     string
+		board    func(t *testing.T) *level.Board
+		expected level.TrackMetrics
+	}{
+		{
+			name:     "ring",
+			board:    ringBoard,
+			expected: level.TrackMetrics{SmoothCorners: 6},
+		},
+		{
+			name:     "oval",
+			board:    oval,
+			expected: level.TrackMetrics{SmoothCorners: 2, SharpCorners: 2},
+		},
+		{
+			name: "split",
+			board: func(t *testing.T) *level.Board {
+				// The split only keeps the lap if the lap turns to the left
+				// at it, since the straightest branch prefers the left one.
+				// Turning the start tile around makes the lap run the other
+				// way around the oval.
+				board := oval(t)
+				start := board.Tile(board.Center())
+				start.Rotation = 0
+				board.SetTile(board.Center(), start)
+				return withShape(board, level.C(2, 0), level.ShapeKindRoadSplit)
+			},
+			expected: level.TrackMetrics{SmoothCorners: 1, SharpCorners: 2, Splits: 1},
+		},
+		{
+			name: "junction",
+			board: func(t *testing.T) *level.Board {
+				return withShape(oval(t), level.C(1, 0), level.ShapeKindRoadJunction)
+			},
+			expected: level.TrackMetrics{SmoothCorners: 2, SharpCorners: 2, Splits: 1},
+		},
+		{
+			name: "crossroads",
+			board: func(t *testing.T) *level.Board {
+				return withShape(oval(t), level.C(1, 0), level.ShapeKindRoadCrossroads)
+			},
+			expected: level.TrackMetrics{SmoothCorners: 2, SharpCorners: 2, Intersections: 1},
+		},
+		{
+			name: "slope",
+			board: func(t *testing.T) *level.Board {
+				return withShape(oval(t), level.C(1, 0), level.ShapeKindRoadSlope)
+			},
+			expected: level.TrackMetrics{SmoothCorners: 2, SharpCorners: 2, Slopes: 1},
+		},
+		{
+			name: "dead end off the lap",
+			board: func(t *testing.T) *level.Board {
+				board := ringBoard(t)
+				board.SetTile(level.C(0, 0), roadTile(t, 0))
+				return board
+			},
+			expected: level.TrackMetrics{SmoothCorners: 6, DeadEnds: 1},
+		},
+	}
+	for _, tc := range testCases {
+		metrics := tc.board(t).Metrics()
+		if metrics.SmoothCorners != tc.expected.SmoothCorners ||
+			metrics.SharpCorners != tc.expected.SharpCorners ||
+			metrics.Splits != tc.expected.Splits ||
+			metrics.Intersections != tc.expected.Intersections ||
+			metrics.DeadEnds != tc.expected.DeadEnds ||
+			metrics.Slopes != tc.expected.Slopes {
+			t.Errorf("%s: got %d smooth, %d sharp, %d splits, %d intersections, %d dead ends and %d slopes",
+				tc.name, metrics.SmoothCorners, metrics.SharpCorners, metrics.Splits,
+				metrics.Intersections, metrics.DeadEnds, metrics.Slopes)
+		}
+	}
+}
+
+func TestBoardMetricsIgnoreRoadsOffTheLap(t *testing.T) {
+	board := ringBoard(t)
+	// A separate loop elsewhere on the board is not part of the lap.
+	board.SetTile(level.C(0, 4), roadTile(t, 0, 5))
+	board.SetTile(level.C(1, 4), roadTile(t, 3, 4))
+	board.SetTile(level.C(0, 3), roadTile(t, 1, 2))
+	metrics := board.Metrics()
+	if metrics.SmoothCorners != 6 || metrics.SharpCorners != 0 {
+		t.Errorf("expected only the 6 corners of the lap, got %d smooth and %d sharp", metrics.SmoothCorners, metrics.SharpCorners)
+	}
+	if metrics.Difficulty != 5.0 {
+		t.Errorf("expected difficulty 5.0, got %.2f", metrics.Difficulty)
+	}
+	if expected := 9 * level.TileSpacing; metrics.RoadLength != expected {
+		t.Errorf("expected road length %.0f, got %.0f", expected, metrics.RoadLength)
+	}
+}
+
+func TestBoardMetricsRotationInvariant(t *testing.T) {
+	original, err := level.ParseBoardText(`
+		layout square 7
+		biome meadow
+		K2  C0  C1  C0  .0  C1  C0
+		  S2  K4  K2  K5  K3  K0  C5
+		K2  K5  C1  K5  .3  K3  Y2
+		  Y1  C4  C1  S0  K0  K1  C5
+		K3  C0  C2  C1  Y0  C2  K4
+		  .1  S5  K4  K2  K5  Y3  K0
+		.2  .4  C3  S3  K5  K3  C4
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := original.Metrics()
+	for steps := 1; steps < 6; steps++ {
+		board, err := original.Rotate(steps)
+		if err != nil {
+			t.Fatalf("rotation by %d steps: %v", steps, err)
+		}
+		if actual := board.Metrics(); actual != expected {
+			t.Errorf("rotation by %d steps: expected %+v, got %+v", steps, expected, actual)
+		}
+	}
+}
+
+// replaceTile replaces the tile at the specified coordinate with a tile of
+// the specified shape that has at least the same roads, if there is one
+// whose additional roads the lap does not take.
+func replaceTile(board *level.Board, coord level.Coord, shape level.ShapeKind) bool {
+	original := board.Tile(coord)
+	originalRoute, err := board.Route(level.BranchRuleStraight)
+	if err != nil {
+		return false
+	}
+	for rotation := range byte(6) {
+		tile := original
+		tile.Shape, tile.Rotation = shape, rotation
+		covers := true
+		for direction := range byte(6) {
+			if original.HasRoad(direction) && !tile.HasRoad(direction) {
+				covers = false
+			}
+		}
+		if !covers {
+			continue
+		}
+		board.SetTile(coord, tile)
+		if route, err := board.Route(level.BranchRuleStraight); err == nil && len(route) == len(originalRoute) {
+			return true
+		}
+	}
+	board.SetTile(coord, original)
+	return false
+}
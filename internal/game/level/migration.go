@@ -0,0 +1,86 @@
+package level
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// BoardFormatVersion is the version of the JSON board format that is
+// produced by SerializeBoard. Boards that were saved before versioning was
+// introduced have no version field and are treated as version 0.
+const BoardFormatVersion = 4
+
+var ErrUnsupportedBoardVersion = errors.New("unsupported board version")
+
+type boardMigration func(document map[string]json.RawMessage) error
+
+// boardMigrations holds the upgrade steps between format versions, where
+// the migration at index N upgrades a document from version N to N+1.
+var boardMigrations = [BoardFormatVersion]boardMigration{
+	migrateBoardV0,
+	migrateBoardV1,
+	migrateBoardV2,
+	migrateBoardV3,
+}
+
+func migrateBoardDocument(data []byte) ([]byte, error) {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	var version int
+	if rawVersion, ok := document["version"]; ok {
+		if err := json.Unmarshal(rawVersion, &version); err != nil {
+			return nil, fmt.Errorf("failed to parse board version: %w", err)
+		}
+	}
+	if version < 0 || version > BoardFormatVersion {
+		return nil, fmt.Errorf("%w: board has version %d but only versions up to %d are supported", ErrUnsupportedBoardVersion, version, BoardFormatVersion)
+	}
+	if version == BoardFormatVersion {
+		return data, nil
+	}
+
+	for ; version < BoardFormatVersion; version++ {
+		if err := boardMigrations[version](document); err != nil {
+			return nil, fmt.Errorf("failed to migrate board from version %d: %w", version, err)
+		}
+	}
+	document["version"] = json.RawMessage(fmt.Sprint(version))
+	return json.Marshal(document)
+}
+
+// migrateBoardV0 upgrades unversioned boards. The tile layout is unchanged,
+// so only the version field needs to be introduced.
+func migrateBoardV0(document map[string]json.RawMessage) error {
+	return nil
+}
+
+// migrateBoardV1 upgrades square boards to the layout description that
+// supports rectangular and hexagon boards.
+func migrateBoardV1(document map[string]json.RawMessage) error {
+	var size int
+	if err := json.Unmarshal(document["size"], &size); err != nil {
+		return fmt.Errorf("failed to parse size: %w", err)
+	}
+	delete(document, "size")
+	document["layout"] = json.RawMessage(fmt.Sprint(LayoutKindRectangle))
+	document["width"] = json.RawMessage(fmt.Sprint(size))
+	document["height"] = json.RawMessage(fmt.Sprint(size))
+	return nil
+}
+
+// migrateBoardV2 upgrades boards from before biomes were introduced, all of
+// which used the meadow surfaces.
+func migrateBoardV2(document map[string]json.RawMessage) error {
+	document["biome"] = json.RawMessage(fmt.Sprint(BiomeKindMeadow))
+	return nil
+}
+
+// migrateBoardV3 upgrades boards from before tiles had an elevation. Tiles
+// without one are at the lowest level, so no changes are needed.
+func migrateBoardV3(document map[string]json.RawMessage) error {
+	return nil
+}
@@ -0,0 +1,86 @@
+package level_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestParseBoardMigratesEachVersion(t *testing.T) {
+	oval := ovalBoard(t)
+	data, err := level.SerializeBoard(oval)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var current map[string]json.RawMessage
+	if err := json.Unmarshal(data, &current); err != nil {
+		t.Fatal(err)
+	}
+	var tiles []map[string]json.RawMessage
+	if err := json.Unmarshal(current["tiles"], &tiles); err != nil {
+		t.Fatal(err)
+	}
+	for _, tile := range tiles {
+		delete(tile, "elevation")
+	}
+	tilesWithoutElevation, err := json.Marshal(tiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	documents := map[int]map[string]json.RawMessage{
+		// Version 0 had no version field and only square boards.
+		0: {
+			"size":  json.RawMessage("3"),
+			"tiles": tilesWithoutElevation,
+		},
+		// Version 1 only added the version field.
+		1: {
+			"version": json.RawMessage("1"),
+			"size":    json.RawMessage("3"),
+			"tiles":   tilesWithoutElevation,
+		},
+		// Version 2 introduced the layout, but had no biome.
+		2: {
+			"version": json.RawMessage("2"),
+			"layout":  json.RawMessage("0"),
+			"width":   json.RawMessage("3"),
+			"height":  json.RawMessage("3"),
+			"tiles":   tilesWithoutElevation,
+		},
+		// Version 3 introduced the biome, but tiles had no elevation.
+		3: {
+			"version": json.RawMessage("3"),
+			"layout":  json.RawMessage("0"),
+			"width":   json.RawMessage("3"),
+			"height":  json.RawMessage("3"),
+			"biome":   json.RawMessage("0"),
+			"tiles":   tilesWithoutElevation,
+		},
+	}
+	for version, document := range documents {
+		name := fmt.Sprintf("version %d", version)
+		data, err := json.Marshal(document)
+		if err != nil {
+			t.Fatal(err)
+		}
+		board, err := level.ParseBoard(data)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		checkSameBoard(t, name, board, oval)
+	}
+}
+
+func TestParseBoardRejectsUnsupportedVersion(t *testing.T) {
+	for _, version := range []int{-1, level.BoardFormatVersion + 1} {
+		data := fmt.Sprintf(`{"version": %d, "layout": 0, "width": 1, "height": 1, "biome": 0, "tiles": []}`, version)
+		if _, err := level.ParseBoard([]byte(data)); !errors.Is(err, level.ErrUnsupportedBoardVersion) {
+			t.Errorf("version %d: expected an unsupported board version, got %v", version, err)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package level
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/mokiat/gomath/dprec"
+)
+
+var ErrNoRoute = errors.New("no route")
+
+const (
+	// BranchRuleLeft takes the branch that turns the most to the left, as
+	// seen on the board map, where rows grow downwards.
+	BranchRuleLeft BranchRule = iota
+
+	// BranchRuleRight takes the branch that turns the most to the right, as
+	// seen on the board map, where rows grow downwards.
+	BranchRuleRight
+
+	// BranchRuleStraight takes the branch that turns the least, preferring
+	// the left one when two branches turn equally.
+	BranchRuleStraight
+)
+
+// BranchRule determines which exit a route takes when a tile offers more
+// than one, such as on splits.
+type BranchRule byte
+
+func (r BranchRule) IsValid() bool {
+	return r <= BranchRuleStraight
+}
+
+// RouteStep is a single tile along a route, along with the directions
+// through which the route enters and exits the tile.
+type RouteStep struct {
+	Coord Coord
+	Entry byte
+	Exit  byte
+}
+
+// Checkpoint is a point along a route that a vehicle needs to pass.
+type Checkpoint struct {
+	// Step is the index of the route step at whose exit the checkpoint is
+	// placed.
+	Step int
+
+	// Position is the world position of the middle of the checkpoint.
+	Position dprec.Vec3
+
+	// Direction is the horizontal unit vector in which the route passes
+	// through the checkpoint.
+	Direction dprec.Vec3
+}
+
+// Route follows the road from the start tile until it returns to it and
+// returns the tiles along the way, using the specified rule whenever the
+// road branches. The direction in which the route leaves the start tile
+// depends only on the shape and rotation of that tile, so rotated boards
+// produce the same route. It fails if the road ends or if the route ends up in a loop that
+// does not pass through the start tile.
+func (b *Board) Route(rule BranchRule) ([]RouteStep, error) {
+	if !rule.IsValid() {
+		return nil, fmt.Errorf("%w: branch rule %d is not known", ErrNoRoute, rule)
+	}
+	start := b.Center()
+	startTile := b.Tile(start)
+	if !startTile.HasAnyRoad() {
+		return nil, fmt.Errorf("%w: start tile has no road", ErrNoRoute)
+	}
+	exit := startTile.startExit()
+	entry := startTile.startEntry(exit)
+
+	type routeState struct {
+		coord Coord
+		entry byte
+	}
+	visited := make(map[routeState]struct{})
+	var result []RouteStep
+	coord := start
+	for {
+		result = append(result, RouteStep{
+			Coord: coord,
+			Entry: entry,
+			Exit:  exit,
+		})
+		visited[routeState{coord, entry}] = struct{}{}
+		if !b.IsRoadConnected(coord, exit) {
+			return nil, fmt.Errorf("%w: road ends at %s", ErrNoRoute, coord)
+		}
+		coord = coord.Neighbor(exit)
+		entry = oppositeDirection(exit)
+		if coord == start && entry == result[0].Entry {
+			return result, nil
+		}
+		if _, ok := visited[routeState{coord, entry}]; ok {
+			return nil, fmt.Errorf("%w: route loops at %s without returning to the start tile", ErrNoRoute, coord)
+		}
+		var ok bool
+		if exit, ok = b.Tile(coord).routeExit(entry, rule); !ok {
+			return nil, fmt.Errorf("%w: road ends at %s", ErrNoRoute, coord)
+		}
+	}
+}
+
+// Checkpoints places a checkpoint at the exit of every interval-th step of
+// the route, starting with the exit of the start tile.
+func (b *Board) Checkpoints(route []RouteStep, interval int) []Checkpoint {
+	interval = max(interval, 1)
+	var result []Checkpoint
+	for i := 0; i < len(route); i += interval {
+		step := route[i]
+		direction := dprec.Vec3Diff(
+			TilePosition(step.Coord.Neighbor(step.Exit), 0),
+			TilePosition(step.Coord, 0),
+		)
+		result = append(result, Checkpoint{
+			Step:      i,
+			Position:  b.EdgeWorldPosition(step.Coord, step.Exit),
+			Direction: dprec.UnitVec3(direction),
+		})
+	}
+	return result
+}
+
+// startExit returns the direction in which a route leaves the start tile,
+// which is the lowest road of the shape before the tile is rotated, so that
+// the exit turns along with the tile.
+func (t Tile) startExit() byte {
+	shape, _ := defaultTileCatalog.shape(t.Shape)
+	for direction := range byte(6) {
+		if shape.roads.contains(direction) {
+			return (direction + 6 - t.Rotation%6) % 6
+		}
+	}
+	return 0
+}
+
+// startEntry returns the direction through which a route that leaves the
+// start tile in the specified direction returns to it. It is the road that
+// is connected to the exit and leads closest to straight through the tile,
+// so that corners and splits can serve as start tiles as well. Dead ends
+// are entered through their only road.
+func (t Tile) startEntry(exit byte) byte {
+	group := t.RoadGroup(exit)
+	opposite := oppositeDirection(exit)
+	// The offsets alternate around the opposite direction, so that the
+	// choice turns along with the tile.
+	for _, offset := range []byte{0, 5, 1, 4, 2} {
+		if entry := (opposite + offset) % 6; entry != exit && slices.Contains(group, entry) {
+			return entry
+		}
+	}
+	return exit
+}
+
+// routeExit returns the exit that a route that enters the tile in the
+// specified direction takes according to the rule.
+func (t Tile) routeExit(entry byte, rule BranchRule) (byte, bool) {
+	heading := oppositeDirection(entry)
+	var (
+		result    byte
+		bestScore int
+		found     bool
+	)
+	for _, exit := range t.RoadGroup(entry) {
+		if exit == entry {
+			continue
+		}
+		// A turn of 1 or 2 steps is to the right and one of 4 or 5 steps is
+		// to the left, since directions grow clockwise on the board map.
+		turn := int((exit + 6 - heading) % 6)
+		var score int
+		switch rule {
+		case BranchRuleLeft:
+			score = (turn + 3) % 6
+		case BranchRuleRight:
+			score = 6 - (turn+3)%6
+		case BranchRuleStraight:
+			score = 2 * min(turn, 6-turn)
+			if turn > 0 && turn < 3 {
+				score++
+			}
+		}
+		if !found || score < bestScore {
+			result, bestScore, found = exit, score, true
+		}
+	}
+	return result, found
+}
@@ -0,0 +1,95 @@
+package level_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mokiat/gomath/dprec"
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+// ringBoard returns a board whose only road is a loop of six corners around
+// the tile above the center, so that the start tile is a corner.
+func ringBoard(t *testing.T) *level.Board {
+	t.Helper()
+	board := level.NewBoard(level.SquareLayout(5))
+	for _, coord := range board.Coords() {
+		board.SetTile(coord, level.Tile{Shape: level.ShapeKindTerrain, Ground: level.GroundKindGrass, Road: level.RoadKindDirt})
+	}
+	ring := level.C(2, 1).Ring(1)
+	for i, coord := range ring {
+		previous, _ := coord.DirectionTo(ring[(i+len(ring)-1)%len(ring)])
+		next, _ := coord.DirectionTo(ring[(i+1)%len(ring)])
+		board.SetTile(coord, roadTile(t, previous, next))
+	}
+	return board
+}
+
+func TestBoardRouteFromCorner(t *testing.T) {
+	board := ringBoard(t)
+	for _, rule := range []level.BranchRule{level.BranchRuleLeft, level.BranchRuleRight, level.BranchRuleStraight} {
+		route, err := board.Route(rule)
+		if err != nil {
+			t.Fatalf("rule %d: %v", rule, err)
+		}
+		if len(route) != 6 {
+			t.Fatalf("rule %d: expected 6 steps, got %d", rule, len(route))
+		}
+		if route[0].Coord != board.Center() {
+			t.Errorf("rule %d: route starts at %s", rule, route[0].Coord)
+		}
+		for i, step := range route {
+			next := route[(i+1)%len(route)]
+			if step.Coord.Neighbor(step.Exit) != next.Coord {
+				t.Errorf("rule %d: step %d exits to %s instead of %s", rule, i, step.Coord.Neighbor(step.Exit), next.Coord)
+			}
+			if next.Coord.Neighbor(next.Entry) != step.Coord {
+				t.Errorf("rule %d: step %d is not entered from the previous step", rule, (i+1)%len(route))
+			}
+		}
+	}
+}
+
+func TestBoardRouteFailsOnTerrainStart(t *testing.T) {
+	board := ringBoard(t)
+	board.SetTile(level.C(2, 2), level.Tile{Shape: level.ShapeKindTerrain, Ground: level.GroundKindGrass, Road: level.RoadKindDirt})
+	if _, err := board.Route(level.BranchRuleStraight); err == nil {
+		t.Error("expected an error")
+	}
+	if _, err := board.Route(level.BranchRule(7)); err == nil {
+		t.Error("expected an error for an unknown branch rule")
+	}
+}
+
+func TestBoardCheckpoints(t *testing.T) {
+	board := ringBoard(t)
+	route, err := board.Route(level.BranchRuleStraight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkpoints := board.Checkpoints(route, 2)
+	if len(checkpoints) != 3 {
+		t.Fatalf("expected 3 checkpoints, got %d", len(checkpoints))
+	}
+	for i, checkpoint := range checkpoints {
+		if checkpoint.Step != 2*i {
+			t.Errorf("checkpoint %d: expected step %d, got %d", i, 2*i, checkpoint.Step)
+		}
+		step := route[checkpoint.Step]
+		if position := board.EdgeWorldPosition(step.Coord, step.Exit); dprec.Vec3Diff(checkpoint.Position, position).Length() > 1e-9 {
+			t.Errorf("checkpoint %d: expected position %v, got %v", i, position, checkpoint.Position)
+		}
+		if length := checkpoint.Direction.Length(); math.Abs(length-1.0) > 1e-9 {
+			t.Errorf("checkpoint %d: direction has length %f", i, length)
+		}
+		// The checkpoint lies on the border of the tile in the direction in
+		// which the route passes through it.
+		toCheckpoint := dprec.Vec3Diff(checkpoint.Position, board.WorldPosition(step.Coord))
+		if dprec.Vec3Dot(dprec.UnitVec3(toCheckpoint), checkpoint.Direction) < 0.999 {
+			t.Errorf("checkpoint %d: direction %v does not point across the border", i, checkpoint.Direction)
+		}
+	}
+	if all := board.Checkpoints(route, 0); len(all) != len(route) {
+		t.Errorf("expected a checkpoint for each of the %d steps, got %d", len(route), len(all))
+	}
+}
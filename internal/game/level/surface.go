@@ -0,0 +1,123 @@
+package level
+
+import "math/rand/v2"
+
+const (
+	BiomeKindMeadow BiomeKind = iota
+)
+
+// BiomeKind determines the grounds and roads that are used for a board.
+// There are only meadows for now, since tiles.glb has no models for the
+// other grounds and roads.
+type BiomeKind byte
+
+func (k BiomeKind) IsValid() bool {
+	return k <= BiomeKindMeadow
+}
+
+// GroundsCanTouch reports whether tiles with the specified grounds can be
+// placed next to each other. Grass and gravel act as transitions between
+// the grounds that cannot touch directly.
+func GroundsCanTouch(a, b GroundKind) bool {
+	switch {
+	case a == b:
+		return true
+	case a == GroundKindGrass || b == GroundKindGrass:
+		return true
+	case a == GroundKindGravel || b == GroundKindGravel:
+		return true
+	default:
+		return false
+	}
+}
+
+// RoadsCanConnect reports whether roads with the specified surfaces can
+// continue into each other. Gravel acts as a transition between the
+// surfaces that cannot connect directly.
+func RoadsCanConnect(a, b RoadKind) bool {
+	return a == b || a == RoadKindGravel || b == RoadKindGravel
+}
+
+type biomeSurfaces struct {
+	ground      GroundKind
+	patchGround GroundKind
+	roads       []RoadKind
+}
+
+var biomes = map[BiomeKind]biomeSurfaces{
+	BiomeKindMeadow: {
+		ground: GroundKindGrass,
+		roads:  []RoadKind{RoadKindDirt},
+	},
+}
+
+// paintSurfaces assigns grounds and roads to the tiles of the board
+// according to the biome, leaving the tiles for which keep returns true
+// unchanged. Biomes that have a single ground and road do not use the
+// random source.
+func paintSurfaces(board *Board, biome BiomeKind, random *rand.Rand, keep func(Coord) bool) {
+	surfaces := biomes[biome]
+	coords := board.Coords()
+
+	for _, coord := range coords {
+		if keep(coord) {
+			continue
+		}
+		tile := board.Tile(coord)
+		tile.Ground = surfaces.ground
+		tile.Road = surfaces.roads[0]
+		board.SetTile(coord, tile)
+	}
+
+	// Scatter patches of the secondary ground.
+	if surfaces.patchGround != GroundKindNone {
+		patchCount := max(1, len(coords)/20)
+		for range patchCount {
+			center := coords[random.IntN(len(coords))]
+			for _, coord := range center.Range(random.IntN(2)) {
+				if board.ContainsCoord(coord) && !keep(coord) {
+					tile := board.Tile(coord)
+					tile.Ground = surfaces.patchGround
+					board.SetTile(coord, tile)
+				}
+			}
+		}
+	}
+
+	// Pick a road surface for each road segment between junctions.
+	if len(surfaces.roads) > 1 {
+		graph := board.RoadGraph()
+		for _, edge := range graph.Edges {
+			road := surfaces.roads[random.IntN(len(surfaces.roads))]
+			for _, coord := range edge.Coords {
+				if !keep(coord) {
+					tile := board.Tile(coord)
+					tile.Road = road
+					board.SetTile(coord, tile)
+				}
+			}
+		}
+	}
+
+	// Use transition surfaces where incompatible surfaces meet.
+	for _, coord := range coords {
+		if keep(coord) {
+			continue
+		}
+		tile := board.Tile(coord)
+		for direction := range byte(6) {
+			neighborCoord := coord.Neighbor(direction)
+			if !board.ContainsCoord(neighborCoord) {
+				continue
+			}
+			neighborTile := board.Tile(neighborCoord)
+			if !GroundsCanTouch(tile.Ground, neighborTile.Ground) {
+				tile.Ground = GroundKindGrass
+			}
+			if board.IsRoadConnected(coord, direction) && !RoadsCanConnect(tile.Road, neighborTile.Road) {
+				tile.Road = RoadKindGravel
+			}
+		}
+		board.SetTile(coord, tile)
+	}
+}
@@ -0,0 +1,322 @@
+package level
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidTextBoard = errors.New("invalid text board")
+
+var (
+	shapeGlyphs = map[ShapeKind]byte{
+		ShapeKindNone:             '_',
+		ShapeKindTerrain:          '.',
+		ShapeKindRoadStraight:     'S',
+		ShapeKindRoadCornerSmooth: 'C',
+		ShapeKindRoadCornerSharp:  'K',
+		ShapeKindRoadSplit:        'Y',
+		ShapeKindRoadCrossroads:   'X',
+		ShapeKindRoadJunction:     'T',
+		ShapeKindRoadDeadEnd:      'D',
+		ShapeKindRoadOverpass:     'O',
+		ShapeKindRoadSlope:        'R',
+	}
+	groundGlyphs = map[GroundKind]byte{
+		GroundKindNone:   '-',
+		GroundKindGrass:  'g',
+		GroundKindSand:   's',
+		GroundKindSnow:   'n',
+		GroundKindGravel: 'v',
+	}
+	roadGlyphs = map[RoadKind]byte{
+		RoadKindNone:    '-',
+		RoadKindDirt:    'd',
+		RoadKindAsphalt: 'a',
+		RoadKindGravel:  'v',
+	}
+	biomeNames = map[BiomeKind]string{
+		BiomeKindMeadow: "meadow",
+	}
+)
+
+// FormatBoardText returns the board in a readable text format, which starts
+// with a layout line and a biome line, followed by one line per row of the
+// board, for example:
+//
+//	layout square 3
+//	biome meadow
+//	.0  C1  .0
+//	  S0  S0  .0
+//	.0  C4  .0
+//
+// Each tile is written as a shape glyph, followed by its rotation and
+// optional properties: g and r followed by a ground or road glyph, e
+// followed by the elevation and v followed by the variation. The ground
+// and road are only written when they differ from the defaults of the
+// biome. Odd rows are indented, so that the text resembles the board, but
+// the indentation and the amount of whitespace between tiles are ignored
+// when parsing. Hexagon boards only list the tiles that are part of the
+// layout.
+func FormatBoardText(board *Board) (string, error) {
+	var builder strings.Builder
+	layout := board.layout
+	switch layout.Kind {
+	case LayoutKindRectangle:
+		if layout.Width == layout.Height {
+			fmt.Fprintf(&builder, "layout square %d\n", layout.Width)
+		} else {
+			fmt.Fprintf(&builder, "layout rectangle %d %d\n", layout.Width, layout.Height)
+		}
+	case LayoutKindHexagon:
+		fmt.Fprintf(&builder, "layout hexagon %d\n", layout.Radius)
+	default:
+		return "", fmt.Errorf("%w: layout %d is not known", ErrInvalidTextBoard, layout.Kind)
+	}
+	biomeName, ok := biomeNames[board.biome]
+	if !ok {
+		return "", fmt.Errorf("%w: biome %d is not known", ErrInvalidTextBoard, board.biome)
+	}
+	fmt.Fprintf(&builder, "biome %s\n", biomeName)
+
+	tokens := make([]string, len(board.tiles))
+	var width int
+	for _, coord := range board.Coords() {
+		token, err := formatTileText(board.Tile(coord), board.defaultTile())
+		if err != nil {
+			return "", fmt.Errorf("tile at %s: %w", coord, err)
+		}
+		tokens[coord.X+coord.Y*layout.Width] = token
+		width = max(width, len(token))
+	}
+	columnWidth := width + 2
+	for y := range layout.Height {
+		var line strings.Builder
+		if y%2 == 1 {
+			line.WriteString(strings.Repeat(" ", columnWidth/2))
+		}
+		for x := range layout.Width {
+			token := tokens[x+y*layout.Width]
+			if !board.ContainsCoord(C(x, y)) {
+				token = ""
+			}
+			line.WriteString(token)
+			line.WriteString(strings.Repeat(" ", columnWidth-len(token)))
+		}
+		builder.WriteString(strings.TrimRight(line.String(), " "))
+		builder.WriteByte('\n')
+	}
+	return builder.String(), nil
+}
+
+// ParseBoardText parses a board in the text format.
+func ParseBoardText(text string) (*Board, error) {
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	var lines []string
+	var lineNumbers []int
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+			lineNumbers = append(lineNumbers, lineNumber)
+		}
+	}
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("%w: missing layout or biome", ErrInvalidTextBoard)
+	}
+
+	layout, err := parseLayoutText(strings.Fields(lines[0]))
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", lineNumbers[0], err)
+	}
+	board := NewBoard(layout)
+	biomeFields := strings.Fields(lines[1])
+	biomeFound := false
+	for biome, name := range biomeNames {
+		if len(biomeFields) == 2 && biomeFields[0] == "biome" && biomeFields[1] == name {
+			board.biome = biome
+			biomeFound = true
+		}
+	}
+	if !biomeFound {
+		return nil, fmt.Errorf("line %d: %w: bad biome %q", lineNumbers[1], ErrInvalidTextBoard, lines[1])
+	}
+
+	rows := lines[2:]
+	if len(rows) != layout.Height {
+		return nil, fmt.Errorf("%w: expected %d rows but found %d", ErrInvalidTextBoard, layout.Height, len(rows))
+	}
+	for y, row := range rows {
+		lineNumber := lineNumbers[y+2]
+		var coords []Coord
+		for x := range layout.Width {
+			if coord := C(x, y); board.ContainsCoord(coord) {
+				coords = append(coords, coord)
+			}
+		}
+		tokens := strings.Fields(row)
+		if len(tokens) != len(coords) {
+			return nil, fmt.Errorf("line %d: %w: expected %d tiles but found %d", lineNumber, ErrInvalidTextBoard, len(coords), len(tokens))
+		}
+		for i, token := range tokens {
+			tile, err := parseTileText(token, board.defaultTile())
+			if err != nil {
+				return nil, fmt.Errorf("line %d: tile %q: %w", lineNumber, token, err)
+			}
+			board.SetTile(coords[i], tile)
+		}
+	}
+	if err := validateBoard(board); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// defaultTile returns a tile with the ground and road that are omitted in
+// the text format.
+func (b *Board) defaultTile() Tile {
+	surfaces := biomes[b.biome]
+	return Tile{
+		Ground: surfaces.ground,
+		Road:   surfaces.roads[0],
+	}
+}
+
+func parseLayoutText(fields []string) (Layout, error) {
+	numbers := make([]int, 0, 2)
+	for _, field := range fields[min(2, len(fields)):] {
+		number, err := strconv.Atoi(field)
+		if err != nil || number <= 0 || number > MaxLayoutSize {
+			return Layout{}, fmt.Errorf("%w: bad layout size %q", ErrInvalidTextBoard, field)
+		}
+		numbers = append(numbers, number)
+	}
+	var layout Layout
+	switch {
+	case len(fields) == 3 && fields[0] == "layout" && fields[1] == "square":
+		layout = SquareLayout(numbers[0])
+	case len(fields) == 4 && fields[0] == "layout" && fields[1] == "rectangle":
+		layout = RectangleLayout(numbers[0], numbers[1])
+	case len(fields) == 3 && fields[0] == "layout" && fields[1] == "hexagon":
+		layout = HexagonLayout(numbers[0])
+	default:
+		return Layout{}, fmt.Errorf("%w: bad layout %q", ErrInvalidTextBoard, strings.Join(fields, " "))
+	}
+	// The layout is checked before the board is allocated, since the rows
+	// that follow might not match it.
+	if !layout.isConsistent() {
+		return Layout{}, fmt.Errorf("%w: layout can be at most %d tiles wide and high", ErrInvalidTextBoard, MaxLayoutSize)
+	}
+	return layout, nil
+}
+
+func formatTileText(tile, defaults Tile) (string, error) {
+	shapeGlyph, ok := shapeGlyphs[tile.Shape]
+	if !ok {
+		return "", fmt.Errorf("%w: shape %d is not known", ErrInvalidTextBoard, tile.Shape)
+	}
+	if tile.Shape == ShapeKindNone {
+		defaults = Tile{}
+	}
+	token := []byte{shapeGlyph}
+	token = strconv.AppendUint(token, uint64(tile.Rotation), 10)
+	if tile.Ground != defaults.Ground {
+		groundGlyph, ok := groundGlyphs[tile.Ground]
+		if !ok {
+			return "", fmt.Errorf("%w: ground %d is not known", ErrInvalidTextBoard, tile.Ground)
+		}
+		token = append(token, 'g', groundGlyph)
+	}
+	if tile.Road != defaults.Road {
+		roadGlyph, ok := roadGlyphs[tile.Road]
+		if !ok {
+			return "", fmt.Errorf("%w: road %d is not known", ErrInvalidTextBoard, tile.Road)
+		}
+		token = append(token, 'r', roadGlyph)
+	}
+	if tile.Elevation != 0 {
+		token = append(token, 'e')
+		token = strconv.AppendUint(token, uint64(tile.Elevation), 10)
+	}
+	if tile.Variation != 0 {
+		token = append(token, 'v')
+		token = strconv.AppendUint(token, uint64(tile.Variation), 10)
+	}
+	return string(token), nil
+}
+
+func parseTileText(token string, defaults Tile) (Tile, error) {
+	var tile Tile
+	shapeFound := false
+	for shape, glyph := range shapeGlyphs {
+		if token[0] == glyph {
+			tile.Shape = shape
+			shapeFound = true
+		}
+	}
+	if !shapeFound {
+		return Tile{}, fmt.Errorf("%w: unknown shape glyph %q", ErrInvalidTextBoard, token[0])
+	}
+	if tile.Shape != ShapeKindNone {
+		tile.Ground = defaults.Ground
+		tile.Road = defaults.Road
+	}
+
+	// readNumber consumes the decimal number at the start of the remaining
+	// token.
+	rest := token[1:]
+	readNumber := func() (byte, bool) {
+		length := 0
+		for length < len(rest) && rest[length] >= '0' && rest[length] <= '9' {
+			length++
+		}
+		value, err := strconv.ParseUint(rest[:length], 10, 8)
+		rest = rest[length:]
+		return byte(value), err == nil
+	}
+	var ok bool
+	if tile.Rotation, ok = readNumber(); !ok {
+		return Tile{}, fmt.Errorf("%w: bad rotation", ErrInvalidTextBoard)
+	}
+	for len(rest) > 0 {
+		property := rest[0]
+		rest = rest[1:]
+		switch property {
+		case 'g', 'r':
+			if len(rest) == 0 {
+				return Tile{}, fmt.Errorf("%w: missing surface glyph", ErrInvalidTextBoard)
+			}
+			glyph := rest[0]
+			rest = rest[1:]
+			found := false
+			if property == 'g' {
+				for ground, groundGlyph := range groundGlyphs {
+					if glyph == groundGlyph {
+						tile.Ground, found = ground, true
+					}
+				}
+			} else {
+				for road, roadGlyph := range roadGlyphs {
+					if glyph == roadGlyph {
+						tile.Road, found = road, true
+					}
+				}
+			}
+			if !found {
+				return Tile{}, fmt.Errorf("%w: unknown surface glyph %q", ErrInvalidTextBoard, glyph)
+			}
+		case 'e':
+			if tile.Elevation, ok = readNumber(); !ok {
+				return Tile{}, fmt.Errorf("%w: bad elevation", ErrInvalidTextBoard)
+			}
+		case 'v':
+			if tile.Variation, ok = readNumber(); !ok {
+				return Tile{}, fmt.Errorf("%w: bad variation", ErrInvalidTextBoard)
+			}
+		default:
+			return Tile{}, fmt.Errorf("%w: unknown property %q", ErrInvalidTextBoard, property)
+		}
+	}
+	return tile, nil
+}
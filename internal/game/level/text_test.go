@@ -0,0 +1,22 @@
+package level_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestParseBoardTextRejectsLayoutSize(t *testing.T) {
+	for _, layout := range []string{
+		"layout square 0",
+		"layout square 1025",
+		"layout rectangle 4294967296 4294967296",
+		"layout hexagon 600",
+	} {
+		_, err := level.ParseBoardText(layout + "\nbiome meadow\n")
+		if !errors.Is(err, level.ErrInvalidTextBoard) {
+			t.Errorf("%s: expected an invalid text board, got %v", layout, err)
+		}
+	}
+}
@@ -1,7 +1,7 @@
 package level
 
 import (
-	"fmt"
+	"slices"
 
 	"github.com/mokiat/gomath/dprec"
 )
@@ -13,54 +13,87 @@ const (
 	ShapeKindRoadCornerSmooth
 	ShapeKindRoadCornerSharp
 	ShapeKindRoadSplit
+
+	// The crossroads, junction, dead end and overpass shapes have no models
+	// in tiles.glb yet. Boards can describe them, but they fail validation
+	// and the generator does not produce them.
+	ShapeKindRoadCrossroads
+	ShapeKindRoadJunction
+	ShapeKindRoadDeadEnd
+	ShapeKindRoadOverpass
+	ShapeKindRoadSlope
 )
 
 type ShapeKind byte
 
+func (k ShapeKind) IsValid() bool {
+	return k <= ShapeKindRoadSlope
+}
+
+func ShapeMaskOf(shapes ...ShapeKind) ShapeMask {
+	var mask ShapeMask
+	for _, shape := range shapes {
+		mask |= 1 << shape
+	}
+	return mask
+}
+
+// ShapeMask is a set of shapes.
+type ShapeMask uint16
+
+func (m ShapeMask) Contains(shape ShapeKind) bool {
+	return shape < 16 && m&(1<<shape) != 0
+}
+
 const (
 	GroundKindNone GroundKind = iota
 	GroundKindGrass
+	GroundKindSand
+	GroundKindSnow
+	GroundKindGravel
 )
 
 type GroundKind byte
 
+func (k GroundKind) IsValid() bool {
+	return k <= GroundKindGravel
+}
+
 const (
 	RoadKindNone RoadKind = iota
 	RoadKindDirt
+	RoadKindAsphalt
+	RoadKindGravel
 )
 
 type RoadKind byte
 
+func (k RoadKind) IsValid() bool {
+	return k <= RoadKindGravel
+}
+
 type Tile struct {
 	Shape     ShapeKind  `json:"shape"`
 	Ground    GroundKind `json:"ground"`
 	Road      RoadKind   `json:"road"`
 	Variation byte       `json:"variation"`
 	Rotation  byte       `json:"rotation"`
+	Elevation byte       `json:"elevation"`
 }
 
+// MaxTileElevation is the highest elevation level that a tile can have.
+const MaxTileElevation = 15
+
 func (t Tile) NodeName() string {
-	switch {
-	case t.Shape == ShapeKindNone:
-		return ""
-	case t.Shape == ShapeKindTerrain && t.Ground == GroundKindGrass:
-		const availableVariations = 1
-		return fmt.Sprintf("Tile.Grass.v%d", (t.Variation%availableVariations)+1)
-	case t.Shape == ShapeKindRoadStraight && t.Ground == GroundKindGrass && t.Road == RoadKindDirt:
-		const availableVariations = 1
-		return fmt.Sprintf("Tile.Grass.Dirt.Straight.v%d", (t.Variation%availableVariations)+1)
-	case t.Shape == ShapeKindRoadCornerSmooth && t.Ground == GroundKindGrass && t.Road == RoadKindDirt:
-		const availableVariations = 1
-		return fmt.Sprintf("Tile.Grass.Dirt.Corner.Smooth.v%d", (t.Variation%availableVariations)+1)
-	case t.Shape == ShapeKindRoadCornerSharp && t.Ground == GroundKindGrass && t.Road == RoadKindDirt:
-		const availableVariations = 1
-		return fmt.Sprintf("Tile.Grass.Dirt.Corner.Sharp.v%d", (t.Variation%availableVariations)+1)
-	case t.Shape == ShapeKindRoadSplit && t.Ground == GroundKindGrass && t.Road == RoadKindDirt:
-		const availableVariations = 1
-		return fmt.Sprintf("Tile.Grass.Dirt.Split.v%d", (t.Variation%availableVariations)+1)
-	default:
+	nodeName, ok := t.nodeName()
+	if !ok {
 		panic("cannot resolve node name for tile")
 	}
+	return nodeName
+}
+
+func (t Tile) nodeName() (string, bool) {
+	return defaultTileCatalog.NodeName(t)
 }
 
 func (t Tile) RotationQuat() dprec.Quat {
@@ -68,18 +101,51 @@ func (t Tile) RotationQuat() dprec.Quat {
 	return dprec.RotationQuat(angle, dprec.BasisYVec3())
 }
 
+func (t Tile) HasAnyRoad() bool {
+	for direction := range byte(6) {
+		if t.HasRoad(direction) {
+			return true
+		}
+	}
+	return false
+}
+
 func (t Tile) HasRoad(direction byte) bool {
-	localDirection := (direction + t.Rotation) % 6
-	switch t.Shape {
-	case ShapeKindRoadStraight:
-		return localDirection == 0 || localDirection == 3
-	case ShapeKindRoadCornerSmooth:
-		return localDirection == 1 || localDirection == 3
-	case ShapeKindRoadCornerSharp:
-		return localDirection == 2 || localDirection == 3
-	case ShapeKindRoadSplit:
-		return localDirection == 1 || localDirection == 3 || localDirection == 5
-	default:
-		return false
+	return defaultTileCatalog.HasRoad(t, direction)
+}
+
+func (t Tile) roadMask() roadMask {
+	var result roadMask
+	for direction := range byte(6) {
+		if t.HasRoad(direction) {
+			result |= 1 << direction
+		}
+	}
+	return result
+}
+
+// RoadGroups returns the directions of the roads on the tile, grouped by
+// the roads that are connected to each other. All shapes have a single
+// group, except for the overpass, where the two roads cross at different
+// levels.
+func (t Tile) RoadGroups() [][]byte {
+	return defaultTileCatalog.RoadGroups(t)
+}
+
+// EdgeElevation returns the elevation level of the tile at its border in
+// the specified direction. It differs from the elevation of the tile only
+// for slopes, which are one level higher on their raised side.
+func (t Tile) EdgeElevation(direction byte) int {
+	return defaultTileCatalog.EdgeElevation(t, direction)
+}
+
+// RoadGroup returns the directions of the roads that are connected to the
+// road in the specified direction, including the direction itself.
+func (t Tile) RoadGroup(direction byte) []byte {
+	for _, group := range t.RoadGroups() {
+		if slices.Contains(group, direction) {
+			return group
+		}
 	}
+	return nil
 }
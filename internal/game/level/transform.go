@@ -0,0 +1,332 @@
+package level
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrUnsupportedTransform = errors.New("unsupported board transform")
+
+const (
+	StitchSideRight StitchSide = iota
+	StitchSideBottom
+)
+
+// StitchSide specifies where the second board is placed when stitching.
+type StitchSide byte
+
+// Rotate returns a copy of the board that is rotated around its center by
+// the specified number of 60 degree steps. Hexagon boards keep their
+// layout, whereas rectangular boards grow to fit the rotated tiles and the
+// gaps are filled with terrain.
+func (b *Board) Rotate(steps int) (*Board, error) {
+	return b.transform(
+		func(offset Cube) Cube {
+			return offset.Rotate(steps)
+		},
+		func(tile Tile) (Tile, error) {
+			tile.Rotation = byte(((int(tile.Rotation)-steps)%6 + 6) % 6)
+			return tile, nil
+		},
+	)
+}
+
+// Mirror returns a copy of the board that is mirrored along the vertical
+// axis that passes through its center. It fails if the board contains a
+// shape that has no mirror image among the available shapes.
+func (b *Board) Mirror() (*Board, error) {
+	return b.transform(
+		func(offset Cube) Cube {
+			return Cube{Q: offset.S, R: offset.R, S: offset.Q}
+		},
+		mirrorTile,
+	)
+}
+
+// Crop returns the rectangular region of the board that starts at the
+// specified coordinate. Roads that would lead off the cropped board are
+// cut, so that the result remains valid. The region needs to start on an
+// even row, since odd rows are shifted and would otherwise change which
+// tiles are neighbors, and its center becomes the start tile, so it needs a
+// road there.
+func (b *Board) Crop(origin Coord, width, height int) (*Board, error) {
+	if origin.Y%2 != 0 {
+		return nil, fmt.Errorf("%w: crop region must start on an even row", ErrUnsupportedTransform)
+	}
+	if width <= 0 || height <= 0 || origin.X < 0 || origin.Y < 0 || origin.X+width > b.layout.Width || origin.Y+height > b.layout.Height {
+		return nil, fmt.Errorf("%w: crop region is outside of the board", ErrUnsupportedTransform)
+	}
+	result := NewBoard(RectangleLayout(width, height))
+	result.biome = b.biome
+	for _, coord := range result.Coords() {
+		sourceCoord := C(coord.X+origin.X, coord.Y+origin.Y)
+		if b.ContainsCoord(sourceCoord) {
+			result.SetTile(coord, b.Tile(sourceCoord))
+		} else {
+			result.SetTile(coord, result.fillTile())
+		}
+	}
+	result.cutRoadsOffBoard()
+	if !result.Tile(result.Center()).HasAnyRoad() {
+		return nil, fmt.Errorf("%w: crop region has no road at its center, where the start tile is", ErrUnsupportedTransform)
+	}
+	return validTransformResult(result)
+}
+
+// Pad returns a rectangular copy of the board that is extended by the
+// specified number of terrain tiles on each side. The top padding needs to
+// be even, so that the rows remain aligned, and the padding needs to keep
+// the start tile in the center of the board.
+func (b *Board) Pad(left, top, right, bottom int) (*Board, error) {
+	if left < 0 || top < 0 || right < 0 || bottom < 0 {
+		return nil, fmt.Errorf("%w: padding cannot be negative", ErrUnsupportedTransform)
+	}
+	if top%2 != 0 {
+		return nil, fmt.Errorf("%w: top padding must be even", ErrUnsupportedTransform)
+	}
+	layout := RectangleLayout(left+b.layout.Width+right, top+b.layout.Height+bottom)
+	if center := b.Center(); layout.Center() != C(center.X+left, center.Y+top) {
+		return nil, fmt.Errorf("%w: padding would move the start tile out of the center", ErrUnsupportedTransform)
+	}
+	result := NewBoard(layout)
+	result.biome = b.biome
+	for _, coord := range result.Coords() {
+		sourceCoord := C(coord.X-left, coord.Y-top)
+		if b.ContainsCoord(sourceCoord) {
+			result.SetTile(coord, b.Tile(sourceCoord))
+		} else {
+			result.SetTile(coord, result.fillTile())
+		}
+	}
+	return validTransformResult(result)
+}
+
+// StitchBoards places the second board next to the first one on the
+// specified side and returns the combined rectangular board, which keeps
+// the biome and the start tile of the first board. The combined board is
+// padded with terrain, so that the start tile remains in its center, and
+// areas that are not covered by either board are filled with terrain as
+// well. Stitching at the bottom requires the first board to have an even
+// height, so that the rows remain aligned.
+func StitchBoards(first, second *Board, side StitchSide) (*Board, error) {
+	var secondOffset Coord
+	var width, height int
+	switch side {
+	case StitchSideRight:
+		secondOffset = C(first.layout.Width, 0)
+		width = first.layout.Width + second.layout.Width
+		height = max(first.layout.Height, second.layout.Height)
+	case StitchSideBottom:
+		if first.layout.Height%2 != 0 {
+			return nil, fmt.Errorf("%w: first board must have an even height", ErrUnsupportedTransform)
+		}
+		secondOffset = C(0, first.layout.Height)
+		width = max(first.layout.Width, second.layout.Width)
+		height = first.layout.Height + second.layout.Height
+	default:
+		return nil, fmt.Errorf("%w: unknown side %d", ErrUnsupportedTransform, side)
+	}
+	center := first.Center()
+	left, right := centeredPadding(center.X, width)
+	top, bottom := centeredPadding(center.Y, height)
+	if top%2 != 0 {
+		// Growing the board by a row on each side moves the center down by
+		// one row, which keeps the rows aligned.
+		top++
+		bottom++
+	}
+	firstOffset := C(left, top)
+	secondOffset = C(secondOffset.X+left, secondOffset.Y+top)
+
+	result := NewBoard(RectangleLayout(left+width+right, top+height+bottom))
+	result.biome = first.biome
+	for _, coord := range result.Coords() {
+		firstCoord := C(coord.X-firstOffset.X, coord.Y-firstOffset.Y)
+		secondCoord := C(coord.X-secondOffset.X, coord.Y-secondOffset.Y)
+		switch {
+		case first.ContainsCoord(firstCoord):
+			result.SetTile(coord, first.Tile(firstCoord))
+		case second.ContainsCoord(secondCoord):
+			result.SetTile(coord, second.Tile(secondCoord))
+		default:
+			result.SetTile(coord, result.fillTile())
+		}
+	}
+
+	// The grounds of the two boards can differ, in which case grass is used
+	// along the seam.
+	for _, coord := range result.Coords() {
+		tile := result.Tile(coord)
+		for _, neighborCoord := range coord.Neighbors() {
+			if result.ContainsCoord(neighborCoord) && !GroundsCanTouch(tile.Ground, result.Tile(neighborCoord).Ground) {
+				tile.Ground = GroundKindGrass
+				result.SetTile(coord, tile)
+			}
+		}
+	}
+	return validTransformResult(result)
+}
+
+// transform maps each tile to a new position relative to the center of the
+// board and adjusts the tile itself, so that its roads follow.
+func (b *Board) transform(mapOffset func(Cube) Cube, mapTile func(Tile) (Tile, error)) (*Board, error) {
+	center := b.Center().Axial()
+	coords := b.Coords()
+	offsets := make([]Axial, len(coords))
+	for i, coord := range coords {
+		offsets[i] = mapOffset(coord.Axial().Sub(center).Cube()).Axial()
+	}
+
+	var layout Layout
+	switch b.layout.Kind {
+	case LayoutKindHexagon:
+		layout = HexagonLayout(b.layout.Radius)
+	default:
+		// The new layout is sized around the center, so that the start tile
+		// remains in the center of the board.
+		var above, below int
+		for _, offset := range offsets {
+			above = max(above, -offset.R)
+			below = max(below, offset.R)
+		}
+		height := centeredLength(above, below)
+		var left, right int
+		rowCenter := C(0, height/2).Axial()
+		for _, offset := range offsets {
+			x := rowCenter.Add(offset).Coord().X
+			left = max(left, -x)
+			right = max(right, x)
+		}
+		layout = RectangleLayout(centeredLength(left, right), height)
+	}
+
+	result := NewBoard(layout)
+	result.biome = b.biome
+	for _, coord := range result.Coords() {
+		result.SetTile(coord, result.fillTile())
+	}
+	newCenter := result.Center().Axial()
+	for i, coord := range coords {
+		tile, err := mapTile(b.Tile(coord))
+		if err != nil {
+			return nil, fmt.Errorf("tile at %s: %w", coord, err)
+		}
+		result.SetTile(newCenter.Add(offsets[i]).Coord(), tile)
+	}
+	return validTransformResult(result)
+}
+
+// validTransformResult returns the transformed board if it is valid and
+// only the validation error otherwise.
+func validTransformResult(board *Board) (*Board, error) {
+	if err := validateBoard(board); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// centeredLength returns the smallest length whose center, as computed by
+// Layout.Center, has at least the specified number of tiles before and after
+// it.
+func centeredLength(before, after int) int {
+	if before == after+1 {
+		return 2 * before
+	}
+	return 2*max(before, after) + 1
+}
+
+// centeredPadding returns the padding that is needed before and after a
+// length, so that the specified position within it ends up in the center.
+func centeredPadding(position, length int) (int, int) {
+	total := centeredLength(position, length-1-position)
+	before := total/2 - position
+	return before, total - before - length
+}
+
+// fillTile returns the terrain tile that is used to fill new areas of the
+// board.
+func (b *Board) fillTile() Tile {
+	surfaces, ok := biomes[b.biome]
+	if !ok {
+		surfaces = biomes[BiomeKindMeadow]
+	}
+	return Tile{
+		Shape:  ShapeKindTerrain,
+		Ground: surfaces.ground,
+		Road:   surfaces.roads[0],
+	}
+}
+
+// cutRoadsOffBoard replaces the tiles that have roads leading off the board
+// with tiles that only keep the roads that remain on the board. When no
+// shape has the remaining roads, the tile loses all of them, which can in
+// turn cut the roads of its neighbors.
+func (b *Board) cutRoadsOffBoard() {
+	for changed := true; changed; {
+		changed = false
+		for _, coord := range b.Coords() {
+			tile := b.Tile(coord)
+			var directions []byte
+			var isCut bool
+			for direction := range byte(6) {
+				if !tile.HasRoad(direction) {
+					continue
+				}
+				neighborCoord := coord.Neighbor(direction)
+				if b.ContainsCoord(neighborCoord) && b.Tile(neighborCoord).HasRoad((direction+3)%6) {
+					directions = append(directions, direction)
+				} else {
+					isCut = true
+				}
+			}
+			if isCut {
+				b.SetTile(coord, roadTile(tile, directions))
+				changed = true
+			}
+		}
+	}
+}
+
+// mirrorTile returns the tile that looks like the mirror image of the
+// specified one, when mirrored along the vertical axis.
+func mirrorTile(tile Tile) (Tile, error) {
+	// Rotations are tried starting with the mirrored one, so that shapes
+	// without roads keep their mirrored orientation.
+	preferredRotation := (6 - tile.Rotation%6) % 6
+	for i := range byte(6) {
+		candidate := tile
+		candidate.Rotation = (preferredRotation + i) % 6
+		if tileSidesMatch(tile, candidate, mirrorDirection) {
+			return candidate, nil
+		}
+	}
+	return Tile{}, fmt.Errorf("%w: shape %d has no mirror image", ErrUnsupportedTransform, tile.Shape)
+}
+
+func mirrorDirection(direction byte) byte {
+	return (9 - direction) % 6
+}
+
+// tileSidesMatch reports whether the roads and edge elevations of the first
+// tile, when moved to the directions specified by mapDirection, are the same
+// as those of the second tile.
+func tileSidesMatch(a, b Tile, mapDirection func(byte) byte) bool {
+	for direction := range byte(6) {
+		mapped := mapDirection(direction)
+		if a.HasRoad(direction) != b.HasRoad(mapped) || a.EdgeElevation(direction) != b.EdgeElevation(mapped) {
+			return false
+		}
+		group := a.RoadGroup(direction)
+		otherGroup := b.RoadGroup(mapped)
+		if len(group) != len(otherGroup) {
+			return false
+		}
+		for _, groupDirection := range group {
+			if !slices.Contains(otherGroup, mapDirection(groupDirection)) {
+				return false
+			}
+		}
+	}
+	return true
+}
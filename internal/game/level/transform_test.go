@@ -0,0 +1,146 @@
+package level_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func ovalBoard(t *testing.T) *level.Board {
+	t.Helper()
+	board, err := level.ParseBoardText(`
+		layout square 3
+		biome meadow
+		K2  S3  C0
+		  C3  S3  K5
+		.1  .2  .2
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return board
+}
+
+func TestBoardTransformsKeepStartCentered(t *testing.T) {
+	oval := ovalBoard(t)
+	padded, err := oval.Pad(2, 2, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evenHeight, err := oval.Pad(0, 2, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	twoOvals, err := level.StitchBoards(oval, oval, level.StitchSideRight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name      string
+		transform func() (*level.Board, error)
+		width     int
+		height    int
+	}{
+		{
+			name:      "rotate",
+			transform: func() (*level.Board, error) { return oval.Rotate(1) },
+			width:     4,
+			height:    4,
+		},
+		{
+			name:      "mirror",
+			transform: oval.Mirror,
+			width:     5,
+			height:    3,
+		},
+		{
+			name:      "pad",
+			transform: func() (*level.Board, error) { return oval.Pad(1, 2, 1, 2) },
+			width:     5,
+			height:    7,
+		},
+		{
+			name:      "crop",
+			transform: func() (*level.Board, error) { return padded.Crop(level.C(2, 2), 3, 3) },
+			width:     3,
+			height:    3,
+		},
+		{
+			// The second oval is cut open and, since there are no dead end
+			// models, turns into terrain.
+			name:      "crop through a road",
+			transform: func() (*level.Board, error) { return twoOvals.Crop(level.C(1, 0), 7, 3) },
+			width:     7,
+			height:    3,
+		},
+		{
+			name:      "stitch right",
+			transform: func() (*level.Board, error) { return level.StitchBoards(oval, oval, level.StitchSideRight) },
+			width:     9,
+			height:    3,
+		},
+		{
+			name:      "stitch bottom",
+			transform: func() (*level.Board, error) { return level.StitchBoards(evenHeight, oval, level.StitchSideBottom) },
+			width:     3,
+			height:    11,
+		},
+	}
+	for _, tc := range testCases {
+		board, err := tc.transform()
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if layout := board.Layout(); layout.Width != tc.width || layout.Height != tc.height {
+			t.Errorf("%s: expected a %dx%d board, got %dx%d", tc.name, tc.width, tc.height, layout.Width, layout.Height)
+		}
+		route, err := board.Route(level.BranchRuleStraight)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if len(route) != 6 {
+			t.Errorf("%s: expected a lap of 6 tiles, got %d", tc.name, len(route))
+		}
+	}
+}
+
+func TestBoardTransformsRejectMovingTheStart(t *testing.T) {
+	oval := ovalBoard(t)
+	testCases := []struct {
+		name      string
+		transform func() (*level.Board, error)
+	}{
+		{
+			name:      "pad on one side",
+			transform: func() (*level.Board, error) { return oval.Pad(2, 0, 0, 0) },
+		},
+		{
+			name:      "pad by an odd number of rows",
+			transform: func() (*level.Board, error) { return oval.Pad(0, 1, 0, 1) },
+		},
+		{
+			name:      "crop with terrain at the center",
+			transform: func() (*level.Board, error) { return oval.Crop(level.C(0, 2), 3, 1) },
+		},
+		{
+			name:      "crop on an odd row",
+			transform: func() (*level.Board, error) { return oval.Crop(level.C(0, 1), 3, 2) },
+		},
+		{
+			name:      "stitch below an odd height",
+			transform: func() (*level.Board, error) { return level.StitchBoards(oval, oval, level.StitchSideBottom) },
+		},
+	}
+	for _, tc := range testCases {
+		board, err := tc.transform()
+		if !errors.Is(err, level.ErrUnsupportedTransform) {
+			t.Errorf("%s: expected an unsupported transform, got %v", tc.name, err)
+		}
+		if board != nil {
+			t.Errorf("%s: expected no board along with the error", tc.name)
+		}
+	}
+}
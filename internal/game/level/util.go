@@ -1,9 +1,34 @@
 package level
 
-import "math/rand/v2"
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
 
 func shuffleSlice[T any](random *rand.Rand, slice []T) {
 	random.Shuffle(len(slice), func(i, j int) {
 		slice[i], slice[j] = slice[j], slice[i]
 	})
 }
+
+func oppositeDirection(direction byte) byte {
+	return (direction + 3) % 6
+}
+
+func abs(value int) int {
+	return max(value, -value)
+}
+
+// weightedShuffleSlice orders the slice randomly, such that items with a
+// higher weight are more likely to appear earlier.
+func weightedShuffleSlice[T comparable](random *rand.Rand, slice []T, weights map[T]float64) {
+	keys := make(map[T]float64, len(slice))
+	for _, item := range slice {
+		keys[item] = -math.Log(1.0-random.Float64()) / weights[item]
+	}
+	slices.SortStableFunc(slice, func(a, b T) int {
+		return cmp.Compare(keys[a], keys[b])
+	})
+}
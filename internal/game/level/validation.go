@@ -0,0 +1,268 @@
+package level
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ProblemKindSizeMismatch ProblemKind = iota
+	ProblemKindUnknownShape
+	ProblemKindUnknownGround
+	ProblemKindUnknownRoad
+	ProblemKindInvalidRotation
+	ProblemKindUnsupportedTile
+	ProblemKindRoadOffBoard
+	ProblemKindRoadMismatch
+	ProblemKindOutsideLayout
+	ProblemKindUnknownBiome
+	ProblemKindSurfaceMismatch
+	ProblemKindInvalidElevation
+	ProblemKindElevationMismatch
+	ProblemKindMissingStart
+)
+
+type ProblemKind byte
+
+func (k ProblemKind) String() string {
+	switch k {
+	case ProblemKindSizeMismatch:
+		return "size mismatch"
+	case ProblemKindUnknownShape:
+		return "unknown shape"
+	case ProblemKindUnknownGround:
+		return "unknown ground"
+	case ProblemKindUnknownRoad:
+		return "unknown road"
+	case ProblemKindInvalidRotation:
+		return "invalid rotation"
+	case ProblemKindUnsupportedTile:
+		return "unsupported tile"
+	case ProblemKindRoadOffBoard:
+		return "road off board"
+	case ProblemKindRoadMismatch:
+		return "road mismatch"
+	case ProblemKindOutsideLayout:
+		return "outside layout"
+	case ProblemKindUnknownBiome:
+		return "unknown biome"
+	case ProblemKindSurfaceMismatch:
+		return "surface mismatch"
+	case ProblemKindInvalidElevation:
+		return "invalid elevation"
+	case ProblemKindElevationMismatch:
+		return "elevation mismatch"
+	case ProblemKindMissingStart:
+		return "missing start"
+	default:
+		return fmt.Sprintf("problem %d", k)
+	}
+}
+
+type Problem struct {
+	Kind      ProblemKind
+	Coord     Coord
+	Direction byte
+	Message   string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s at %s: %s", p.Kind, p.Coord, p.Message)
+}
+
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		messages[i] = problem.String()
+	}
+	return fmt.Sprintf("invalid board: %s", strings.Join(messages, "; "))
+}
+
+// Validate checks that the board is consistent and can be played. The size
+// of the board is limited to MaxLayoutSize and the tile in its center needs
+// a road, since the race starts there. It returns all problems that were
+// found or nil if the board is valid.
+func (b *Board) Validate() []Problem {
+	layout := b.layout
+	if !layout.isConsistent() {
+		return []Problem{
+			{
+				Kind:    ProblemKindSizeMismatch,
+				Message: fmt.Sprintf("layout %d with size %dx%d and radius %d is not valid", layout.Kind, layout.Width, layout.Height, layout.Radius),
+			},
+		}
+	}
+	if len(b.tiles) != layout.Width*layout.Height {
+		return []Problem{
+			{
+				Kind:    ProblemKindSizeMismatch,
+				Message: fmt.Sprintf("size %dx%d requires %d tiles but there are %d", layout.Width, layout.Height, layout.Width*layout.Height, len(b.tiles)),
+			},
+		}
+	}
+
+	var problems []Problem
+	if !b.biome.IsValid() {
+		problems = append(problems, Problem{
+			Kind:    ProblemKindUnknownBiome,
+			Message: fmt.Sprintf("biome %d is not known", b.biome),
+		})
+	}
+	for y := range layout.Height {
+		for x := range layout.Width {
+			coord := C(x, y)
+			if !b.ContainsCoord(coord) {
+				if tile := b.tiles[x+y*layout.Width]; tile != (Tile{}) {
+					problems = append(problems, Problem{
+						Kind:    ProblemKindOutsideLayout,
+						Coord:   coord,
+						Message: "tile is outside of the board layout and must be empty",
+					})
+				}
+				continue
+			}
+			problems = append(problems, b.validateTile(coord)...)
+		}
+	}
+	// The race starts on the tile in the center of the board.
+	if center := b.Center(); !b.Tile(center).HasAnyRoad() {
+		problems = append(problems, Problem{
+			Kind:    ProblemKindMissingStart,
+			Coord:   center,
+			Message: "start tile in the center of the board has no road",
+		})
+	}
+	return problems
+}
+
+func validateBoard(board *Board) error {
+	if problems := board.Validate(); len(problems) > 0 {
+		return &ValidationError{
+			Problems: problems,
+		}
+	}
+	return nil
+}
+
+func (b *Board) validateTile(coord Coord) []Problem {
+	tile := b.Tile(coord)
+
+	problems := b.validateTileKinds(coord)
+	if len(problems) > 0 {
+		return problems
+	}
+
+	if _, ok := tile.nodeName(); !ok {
+		problems = append(problems, Problem{
+			Kind:    ProblemKindUnsupportedTile,
+			Coord:   coord,
+			Message: fmt.Sprintf("no model for shape %d with ground %d and road %d", tile.Shape, tile.Ground, tile.Road),
+		})
+	}
+	for direction := range byte(6) {
+		if !tile.HasRoad(direction) {
+			continue
+		}
+		neighborCoord := coord.Neighbor(direction)
+		if !b.ContainsCoord(neighborCoord) {
+			problems = append(problems, Problem{
+				Kind:      ProblemKindRoadOffBoard,
+				Coord:     coord,
+				Direction: direction,
+				Message:   fmt.Sprintf("road in direction %d leads off the board", direction),
+			})
+			continue
+		}
+		if !b.Tile(neighborCoord).HasRoad(oppositeDirection(direction)) {
+			problems = append(problems, Problem{
+				Kind:      ProblemKindRoadMismatch,
+				Coord:     coord,
+				Direction: direction,
+				Message:   fmt.Sprintf("road in direction %d has no continuation at %s", direction, neighborCoord),
+			})
+		}
+	}
+	// Each pair of neighbors is checked only once, from the tile that is
+	// placed first in row-major order.
+	for _, direction := range []byte{0, 1, 2} {
+		neighborCoord := coord.Neighbor(direction)
+		if !b.ContainsCoord(neighborCoord) {
+			continue
+		}
+		neighborTile := b.Tile(neighborCoord)
+		if tile.Shape == ShapeKindNone || neighborTile.Shape == ShapeKindNone {
+			continue
+		}
+		if !GroundsCanTouch(tile.Ground, neighborTile.Ground) {
+			problems = append(problems, Problem{
+				Kind:      ProblemKindSurfaceMismatch,
+				Coord:     coord,
+				Direction: direction,
+				Message:   fmt.Sprintf("ground %d cannot touch ground %d at %s", tile.Ground, neighborTile.Ground, neighborCoord),
+			})
+		}
+		if b.IsRoadConnected(coord, direction) && !RoadsCanConnect(tile.Road, neighborTile.Road) {
+			problems = append(problems, Problem{
+				Kind:      ProblemKindSurfaceMismatch,
+				Coord:     coord,
+				Direction: direction,
+				Message:   fmt.Sprintf("road %d cannot connect to road %d at %s", tile.Road, neighborTile.Road, neighborCoord),
+			})
+		}
+		if b.IsRoadConnected(coord, direction) && tile.EdgeElevation(direction) != neighborTile.EdgeElevation(oppositeDirection(direction)) {
+			problems = append(problems, Problem{
+				Kind:      ProblemKindElevationMismatch,
+				Coord:     coord,
+				Direction: direction,
+				Message:   fmt.Sprintf("road at elevation %d continues at elevation %d at %s", tile.EdgeElevation(direction), neighborTile.EdgeElevation(oppositeDirection(direction)), neighborCoord),
+			})
+		}
+	}
+	return problems
+}
+
+func (b *Board) validateTileKinds(coord Coord) []Problem {
+	tile := b.Tile(coord)
+
+	var problems []Problem
+	if !tile.Shape.IsValid() {
+		problems = append(problems, Problem{
+			Kind:    ProblemKindUnknownShape,
+			Coord:   coord,
+			Message: fmt.Sprintf("shape %d is not known", tile.Shape),
+		})
+	}
+	if !tile.Ground.IsValid() {
+		problems = append(problems, Problem{
+			Kind:    ProblemKindUnknownGround,
+			Coord:   coord,
+			Message: fmt.Sprintf("ground %d is not known", tile.Ground),
+		})
+	}
+	if !tile.Road.IsValid() {
+		problems = append(problems, Problem{
+			Kind:    ProblemKindUnknownRoad,
+			Coord:   coord,
+			Message: fmt.Sprintf("road %d is not known", tile.Road),
+		})
+	}
+	if tile.Rotation >= 6 {
+		problems = append(problems, Problem{
+			Kind:    ProblemKindInvalidRotation,
+			Coord:   coord,
+			Message: fmt.Sprintf("rotation %d is out of range", tile.Rotation),
+		})
+	}
+	if tile.Elevation > MaxTileElevation {
+		problems = append(problems, Problem{
+			Kind:    ProblemKindInvalidElevation,
+			Coord:   coord,
+			Message: fmt.Sprintf("elevation %d is above the maximum of %d", tile.Elevation, MaxTileElevation),
+		})
+	}
+	return problems
+}
@@ -0,0 +1,66 @@
+package level_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestValidateUnsupportedTile(t *testing.T) {
+	board := ovalBoard(t)
+	if problems := board.Validate(); len(problems) > 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+
+	board.SetTile(level.C(1, 2), level.Tile{
+		Shape:  level.ShapeKindTerrain,
+		Ground: level.GroundKindSnow,
+	})
+	problems := board.Validate()
+	if len(problems) != 1 || problems[0].Kind != level.ProblemKindUnsupportedTile || problems[0].Coord != level.C(1, 2) {
+		t.Errorf("expected an unsupported tile at (1,2), got %v", problems)
+	}
+}
+
+func TestValidateMissingStart(t *testing.T) {
+	board := level.NewBoard(level.SquareLayout(3))
+	for _, coord := range board.Coords() {
+		board.SetTile(coord, level.Tile{
+			Shape:  level.ShapeKindTerrain,
+			Ground: level.GroundKindGrass,
+		})
+	}
+	problems := board.Validate()
+	if len(problems) != 1 || problems[0].Kind != level.ProblemKindMissingStart || problems[0].Coord != level.C(1, 1) {
+		t.Errorf("expected a missing start at (1,1), got %v", problems)
+	}
+}
+
+func TestParseBoardRejectsLayoutSize(t *testing.T) {
+	testCases := []struct {
+		name   string
+		layout string
+	}{
+		{
+			name:   "empty",
+			layout: `"width": 0, "height": 0`,
+		},
+		{
+			name:   "too large",
+			layout: `"width": 1025, "height": 1`,
+		},
+		{
+			name:   "overflowing tile count",
+			layout: `"width": 4294967296, "height": 4294967296`,
+		},
+	}
+	for _, tc := range testCases {
+		_, err := level.ParseBoard([]byte(`{"version": ` + strconv.Itoa(level.BoardFormatVersion) + `, ` + tc.layout + `, "tiles": []}`))
+		var validationErr *level.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Problems[0].Kind != level.ProblemKindSizeMismatch {
+			t.Errorf("%s: expected a size mismatch, got %v", tc.name, err)
+		}
+	}
+}
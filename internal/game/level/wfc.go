@@ -0,0 +1,487 @@
+package level
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+const (
+	// wfcMaxCandidates is the maximum number of distinct shape and rotation
+	// combinations that can be tracked per cell.
+	wfcMaxCandidates = 128
+
+	// wfcDensityBias is the factor by which the weights of terrain or road
+	// candidates are reduced when the board deviates from the road density
+	// target.
+	wfcDensityBias = 0.2
+)
+
+// BoardGenerator is a strategy that can produce random boards.
+type BoardGenerator interface {
+	Generate(ctx context.Context) (*Board, error)
+	Stats() GeneratorStats
+}
+
+var (
+	_ BoardGenerator = (*Generator)(nil)
+	_ BoardGenerator = (*WFCGenerator)(nil)
+)
+
+// NewWFCGenerator creates a generator that uses constraint propagation, in
+// the style of wave function collapse, instead of row-major backtracking.
+// It follows the same tile rules and supports the same configuration.
+func NewWFCGenerator(config GeneratorConfig) *WFCGenerator {
+	config.MaxShapeCounts = config.shapeCaps()
+	var candidates []Tile
+	for _, shape := range config.shapes() {
+		for rotation := range byte(6) {
+			candidates = append(candidates, Tile{
+				Shape:    shape,
+				Ground:   GroundKindGrass,
+				Road:     RoadKindDirt,
+				Rotation: rotation,
+			})
+		}
+	}
+
+	// Pinned tiles can have shapes that are not among the weighted shapes.
+	// These become candidates of their own, which only the cells that are
+	// pinned to them can use. Candidates beyond wfcMaxCandidates are not
+	// tracked, which Generate reports as an error.
+	var pinnedOnly wfcSet
+	if config.Pinned != nil {
+		for _, coord := range config.Pinned.Coords() {
+			if !config.isPinned(coord) {
+				continue
+			}
+			pinnedTile := config.Pinned.Tile(coord)
+			candidate := Tile{
+				Shape:    pinnedTile.Shape,
+				Ground:   GroundKindGrass,
+				Road:     RoadKindDirt,
+				Rotation: pinnedTile.Rotation % 6,
+			}
+			if !slices.Contains(candidates, candidate) {
+				if len(candidates) < wfcMaxCandidates {
+					pinnedOnly.add(len(candidates))
+				}
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	return &WFCGenerator{
+		config:     config,
+		random:     config.random(),
+		candidates: candidates,
+		pinnedOnly: pinnedOnly,
+	}
+}
+
+type WFCGenerator struct {
+	config     GeneratorConfig
+	random     *rand.Rand
+	candidates []Tile
+	pinnedOnly wfcSet
+
+	withRoad  [6]wfcSet
+	domains   []wfcSet
+	trail     []wfcTrailEntry
+	queue     []int
+	decisions []wfcDecision
+
+	stats GeneratorStats
+}
+
+func (g *WFCGenerator) Stats() GeneratorStats {
+	return g.stats
+}
+
+func (g *WFCGenerator) Generate(ctx context.Context) (*Board, error) {
+	if g.config.TimeBudget > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, g.config.TimeBudget)
+		defer cancel()
+	}
+	g.stats = GeneratorStats{}
+
+	startTime := time.Now()
+	defer func() {
+		g.stats.Duration = time.Since(startTime)
+	}()
+
+	if err := g.config.checkConstraints(); err != nil {
+		return nil, err
+	}
+	if len(g.candidates) > wfcMaxCandidates {
+		return nil, fmt.Errorf("%w: there are %d tile candidates but at most %d are supported", ErrUnsatisfiableConstraints, len(g.candidates), wfcMaxCandidates)
+	}
+	return generateWithRetries(ctx, g.config, g.random, &g.stats, g.generateBoard)
+}
+
+func (g *WFCGenerator) generateBoard(ctx context.Context) (*Board, error) {
+	board := NewBoard(g.config.Layout)
+	if !g.initialize(board) {
+		return nil, g.failure()
+	}
+
+	for {
+		g.stats.Attempts++
+		if g.stats.Attempts%generatorCancelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("generation interrupted after %d attempts: %w", g.stats.Attempts, err)
+			}
+		}
+
+		cellIndex, ok := g.lowestEntropyCell(board)
+		if !ok {
+			break
+		}
+		g.decisions = append(g.decisions, wfcDecision{
+			trailLength: len(g.trail),
+			cellIndex:   cellIndex,
+			candidate:   g.chooseCandidate(board, cellIndex),
+		})
+		g.stats.DeepestIndex = max(g.stats.DeepestIndex, len(g.decisions))
+
+		decision := g.decisions[len(g.decisions)-1]
+		if g.restrict(cellIndex, wfcSetOf(decision.candidate)) && g.propagate(board) {
+			continue
+		}
+		if !g.backtrack(board) {
+			return nil, g.failure()
+		}
+	}
+
+	for _, coord := range board.Coords() {
+		if g.config.isPinned(coord) {
+			board.SetTile(coord, g.config.Pinned.Tile(coord))
+			continue
+		}
+		cellIndex := g.cellIndex(coord)
+		board.SetTile(coord, g.candidates[g.domains[cellIndex].first()])
+	}
+	return board, nil
+}
+
+func (g *WFCGenerator) initialize(board *Board) bool {
+	layout := g.config.Layout
+
+	for direction := range byte(6) {
+		g.withRoad[direction] = wfcSet{}
+		for i, candidate := range g.candidates {
+			if candidate.HasRoad(direction) {
+				g.withRoad[direction].add(i)
+			}
+		}
+	}
+
+	var all wfcSet
+	for i := range g.candidates {
+		all.add(i)
+	}
+	all = all.difference(g.pinnedOnly)
+	g.domains = make([]wfcSet, layout.Width*layout.Height)
+	g.trail = g.trail[:0]
+	g.queue = g.queue[:0]
+	g.decisions = g.decisions[:0]
+
+	for _, coord := range board.Coords() {
+		cellIndex := g.cellIndex(coord)
+		domain := all
+		if g.config.isPinned(coord) {
+			domain = wfcSet{}
+			pinnedTile := g.config.Pinned.Tile(coord)
+			for i, candidate := range g.candidates {
+				if candidate.Shape == pinnedTile.Shape && candidate.Rotation == pinnedTile.Rotation%6 {
+					domain.add(i)
+				}
+			}
+		}
+		for i, candidate := range g.candidates {
+			switch {
+			case coord == board.Center() && !isValidStartTile(candidate):
+				domain.remove(i)
+			case g.config.ForbiddenShapes[coord].Contains(candidate.Shape):
+				domain.remove(i)
+			}
+		}
+		for direction := range byte(6) {
+			if !board.ContainsCoord(coord.Neighbor(direction)) {
+				domain = domain.difference(g.withRoad[direction])
+			}
+		}
+		if domain.isEmpty() {
+			return false
+		}
+		g.domains[cellIndex] = domain
+		g.queue = append(g.queue, cellIndex)
+	}
+	return g.propagate(board)
+}
+
+func (g *WFCGenerator) failure() error {
+	if g.config.hasConstraints() {
+		return fmt.Errorf("%w: no board matches the pinned tiles and forbidden shapes", ErrUnsatisfiableConstraints)
+	}
+	return ErrGenerationFailed
+}
+
+func (g *WFCGenerator) cellIndex(coord Coord) int {
+	return coord.X + coord.Y*g.config.Layout.Width
+}
+
+func (g *WFCGenerator) cellCoord(cellIndex int) Coord {
+	return C(cellIndex%g.config.Layout.Width, cellIndex/g.config.Layout.Width)
+}
+
+func (g *WFCGenerator) lowestEntropyCell(board *Board) (int, bool) {
+	bestIndex := -1
+	bestEntropy := 0.0
+	for _, coord := range board.Coords() {
+		cellIndex := g.cellIndex(coord)
+		count := g.domains[cellIndex].count()
+		if count <= 1 {
+			continue
+		}
+		// The noise breaks ties randomly without affecting the ordering
+		// between cells with a different number of candidates.
+		entropy := float64(count) + 0.5*g.random.Float64()
+		if bestIndex < 0 || entropy < bestEntropy {
+			bestIndex = cellIndex
+			bestEntropy = entropy
+		}
+	}
+	return bestIndex, bestIndex >= 0
+}
+
+func (g *WFCGenerator) chooseCandidate(board *Board, cellIndex int) int {
+	var (
+		shapeCounts map[ShapeKind]int
+		preferRoads bool
+	)
+	if g.config.RoadDensity > 0.0 || len(g.config.MaxShapeCounts) > 0 {
+		var placedCount int
+		shapeCounts, placedCount = g.decidedShapeCounts(board)
+		roadCount := placedCount - shapeCounts[ShapeKindTerrain]
+		preferRoads = float64(roadCount) < g.config.RoadDensity*float64(placedCount+1)
+	}
+
+	domain := g.domains[cellIndex]
+	weights := make([]float64, len(g.candidates))
+	totalWeight := 0.0
+	for i, candidate := range g.candidates {
+		if !domain.contains(i) {
+			continue
+		}
+		if maxCount, ok := g.config.MaxShapeCounts[candidate.Shape]; ok && shapeCounts[candidate.Shape] >= maxCount {
+			continue
+		}
+		weight := 1.0
+		if g.config.ShapeWeights != nil {
+			weight = g.config.ShapeWeights[candidate.Shape]
+		}
+		if g.config.RoadDensity > 0.0 && (candidate.Shape == ShapeKindTerrain) == preferRoads {
+			weight *= wfcDensityBias
+		}
+		weights[i] = weight
+		totalWeight += weight
+	}
+	if totalWeight <= 0.0 {
+		// Every candidate is capped. Any choice will cause a contradiction
+		// and a backtrack.
+		return domain.first()
+	}
+
+	threshold := g.random.Float64() * totalWeight
+	for i, weight := range weights {
+		if weight <= 0.0 {
+			continue
+		}
+		threshold -= weight
+		if threshold < 0.0 {
+			return i
+		}
+	}
+	return domain.last()
+}
+
+func (g *WFCGenerator) decidedShapeCounts(board *Board) (map[ShapeKind]int, int) {
+	result := make(map[ShapeKind]int)
+	var total int
+	for _, coord := range board.Coords() {
+		domain := g.domains[g.cellIndex(coord)]
+		if domain.count() == 1 {
+			result[g.candidates[domain.first()].Shape]++
+			total++
+		}
+	}
+	return result, total
+}
+
+func (g *WFCGenerator) restrict(cellIndex int, allowed wfcSet) bool {
+	domain := g.domains[cellIndex]
+	restricted := domain.intersection(allowed)
+	if restricted == domain {
+		return true
+	}
+	g.trail = append(g.trail, wfcTrailEntry{
+		cellIndex: cellIndex,
+		domain:    domain,
+	})
+	g.domains[cellIndex] = restricted
+	g.queue = append(g.queue, cellIndex)
+	return !restricted.isEmpty()
+}
+
+func (g *WFCGenerator) propagate(board *Board) bool {
+	for len(g.queue) > 0 {
+		cellIndex := g.queue[len(g.queue)-1]
+		g.queue = g.queue[:len(g.queue)-1]
+
+		domain := g.domains[cellIndex]
+		coord := g.cellCoord(cellIndex)
+		for direction := range byte(6) {
+			neighborCoord := coord.Neighbor(direction)
+			if !board.ContainsCoord(neighborCoord) {
+				continue
+			}
+			oppositeRoads := g.withRoad[oppositeDirection(direction)]
+			canHaveRoad := !domain.intersection(g.withRoad[direction]).isEmpty()
+			canHaveNoRoad := !domain.difference(g.withRoad[direction]).isEmpty()
+
+			neighborIndex := g.cellIndex(neighborCoord)
+			neighborDomain := g.domains[neighborIndex]
+			allowed := neighborDomain
+			if !canHaveRoad {
+				allowed = allowed.difference(oppositeRoads)
+			}
+			if !canHaveNoRoad {
+				allowed = allowed.intersection(oppositeRoads)
+			}
+			if !g.restrict(neighborIndex, allowed) {
+				g.queue = g.queue[:0]
+				return false
+			}
+		}
+	}
+	if len(g.config.MaxShapeCounts) > 0 {
+		shapeCounts, _ := g.decidedShapeCounts(board)
+		for shape, maxCount := range g.config.MaxShapeCounts {
+			if shapeCounts[shape] > maxCount {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// backtrack undoes decisions until one of them can be replaced by a
+// different candidate without an immediate contradiction.
+func (g *WFCGenerator) backtrack(board *Board) bool {
+	for len(g.decisions) > 0 {
+		g.stats.Backtracks++
+		decision := g.decisions[len(g.decisions)-1]
+		g.decisions = g.decisions[:len(g.decisions)-1]
+		g.undo(decision.trailLength)
+
+		var rejected wfcSet
+		rejected.add(decision.candidate)
+		if g.restrict(decision.cellIndex, g.domains[decision.cellIndex].difference(rejected)) && g.propagate(board) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *WFCGenerator) undo(trailLength int) {
+	for len(g.trail) > trailLength {
+		entry := g.trail[len(g.trail)-1]
+		g.trail = g.trail[:len(g.trail)-1]
+		g.domains[entry.cellIndex] = entry.domain
+	}
+}
+
+type wfcDecision struct {
+	trailLength int
+	cellIndex   int
+	candidate   int
+}
+
+type wfcTrailEntry struct {
+	cellIndex int
+	domain    wfcSet
+}
+
+func wfcSetOf(index int) wfcSet {
+	var result wfcSet
+	result.add(index)
+	return result
+}
+
+type wfcSet [wfcMaxCandidates / 64]uint64
+
+func (s *wfcSet) add(index int) {
+	s[index/64] |= 1 << (index % 64)
+}
+
+func (s *wfcSet) remove(index int) {
+	s[index/64] &^= 1 << (index % 64)
+}
+
+func (s wfcSet) contains(index int) bool {
+	return s[index/64]&(1<<(index%64)) != 0
+}
+
+func (s wfcSet) isEmpty() bool {
+	for _, word := range s {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s wfcSet) count() int {
+	var result int
+	for _, word := range s {
+		result += bits.OnesCount64(word)
+	}
+	return result
+}
+
+func (s wfcSet) first() int {
+	for i, word := range s {
+		if word != 0 {
+			return i*64 + bits.TrailingZeros64(word)
+		}
+	}
+	return -1
+}
+
+func (s wfcSet) last() int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] != 0 {
+			return i*64 + 63 - bits.LeadingZeros64(s[i])
+		}
+	}
+	return -1
+}
+
+func (s wfcSet) intersection(other wfcSet) wfcSet {
+	for i := range s {
+		s[i] &= other[i]
+	}
+	return s
+}
+
+func (s wfcSet) difference(other wfcSet) wfcSet {
+	for i := range s {
+		s[i] &^= other[i]
+	}
+	return s
+}
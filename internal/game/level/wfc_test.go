@@ -0,0 +1,75 @@
+package level_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestWFCGeneratorPinnedShapeOnlyAtPinnedCell(t *testing.T) {
+	// Sharp corners are not among the weighted shapes, so they can only
+	// appear at the pinned coordinate.
+	layout := level.SquareLayout(9)
+	pinnedCoord := level.C(2, 2)
+	pinned := level.NewBoard(layout)
+	pinned.SetTile(pinnedCoord, level.Tile{
+		Shape:     level.ShapeKindRoadCornerSharp,
+		Ground:    level.GroundKindGrass,
+		Road:      level.RoadKindDirt,
+		Variation: 1,
+	})
+	weights := map[level.ShapeKind]float64{
+		level.ShapeKindTerrain:          1.0,
+		level.ShapeKindRoadStraight:     1.0,
+		level.ShapeKindRoadCornerSmooth: 1.0,
+		level.ShapeKindRoadSplit:        1.0,
+	}
+
+	for seed := uint64(1); seed <= 5; seed++ {
+		generator := level.NewWFCGenerator(level.GeneratorConfig{
+			Seed:         seed,
+			Layout:       layout,
+			Pinned:       pinned,
+			ShapeWeights: weights,
+		})
+		board, err := generator.Generate(context.Background())
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		for _, coord := range board.Coords() {
+			tile := board.Tile(coord)
+			if coord == pinnedCoord {
+				if tile != pinned.Tile(coord) {
+					t.Errorf("seed %d: pinned tile changed to %+v", seed, tile)
+				}
+				continue
+			}
+			if tile.Shape == level.ShapeKindRoadCornerSharp {
+				t.Errorf("seed %d: unexpected sharp corner at %s", seed, coord)
+			}
+		}
+	}
+}
+
+func TestWFCGeneratorRejectsTooManyPinnedShapes(t *testing.T) {
+	layout := level.SquareLayout(13)
+	pinned := level.NewBoard(layout)
+	for i, coord := range pinned.Coords() {
+		pinned.SetTile(coord, level.Tile{
+			Shape:    level.ShapeKind(16 + i/6),
+			Rotation: byte(i % 6),
+			Ground:   level.GroundKindGrass,
+			Road:     level.RoadKindDirt,
+		})
+	}
+	_, err := level.NewWFCGenerator(level.GeneratorConfig{
+		Seed:   1,
+		Layout: layout,
+		Pinned: pinned,
+	}).Generate(context.Background())
+	if !errors.Is(err, level.ErrUnsatisfiableConstraints) {
+		t.Errorf("expected unsatisfiable constraints, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package level
+
+import (
+	"math"
+
+	"github.com/mokiat/gomath/dprec"
+)
+
+const (
+	// TileSize is the size of a tile in meters, measured between two
+	// opposite corners.
+	TileSize = 80.0
+
+	// TileElevationHeight is the height in meters of a single elevation
+	// level.
+	TileElevationHeight = 5.0
+)
+
+// TileSpacing is the distance in meters between the centers of two
+// neighboring tiles.
+var TileSpacing = TileSize * math.Sqrt(3) / 2.0
+
+// slopeAngle is the angle by which slopes are tilted, so that they climb a
+// single elevation level between their opposite sides.
+var slopeAngle = math.Atan2(TileElevationHeight, TileSpacing)
+
+// TilePosition returns the world position of the center of a tile at the
+// specified coordinate and elevation, where rows run along the Z axis.
+func TilePosition(coord Coord, elevation int) dprec.Vec3 {
+	x, y := coord.X, coord.Y
+	xShift := TileSpacing
+	yShift := TileSize * 3.0 / 4.0
+	xOffset := float64(0.0)
+	if max(y, -y)%2 == 1 {
+		xOffset = xShift / 2.0
+	}
+	return dprec.Vec3{
+		X: float64(x)*xShift + xOffset,
+		Y: float64(elevation) * TileElevationHeight,
+		Z: float64(y) * yShift,
+	}
+}
+
+// WorldPosition returns the position of the center of the tile at the
+// specified coordinate in the world, where the start tile is placed at the
+// origin. Slopes are centered halfway between their two levels.
+func (b *Board) WorldPosition(coord Coord) dprec.Vec3 {
+	center := b.Center()
+	position := TilePosition(coord, int(b.Tile(coord).Elevation))
+	if b.Tile(coord).Shape == ShapeKindRoadSlope {
+		position.Y += TileElevationHeight / 2.0
+	}
+	return dprec.Vec3Diff(position, TilePosition(center, int(b.Tile(center).Elevation)))
+}
+
+// WorldRotation returns the rotation in the world of the model of the tile
+// at the specified coordinate. Slopes use the model of a straight road,
+// which is tilted so that it climbs towards the raised side.
+func (b *Board) WorldRotation(coord Coord) dprec.Quat {
+	tile := b.Tile(coord)
+	rotation := tile.RotationQuat()
+	if tile.Shape == ShapeKindRoadSlope {
+		// The raised side of the slope model faces the X axis.
+		rotation = dprec.QuatProd(rotation, dprec.RotationQuat(dprec.Radians(slopeAngle), dprec.BasisZVec3()))
+	}
+	return rotation
+}
+
+// EdgeWorldPosition returns the position in the world of the middle of the
+// border of the tile at the specified coordinate in the specified direction.
+func (b *Board) EdgeWorldPosition(coord Coord, direction byte) dprec.Vec3 {
+	center := b.Center()
+	neighbor := coord.Neighbor(direction)
+	position := dprec.Vec3Quot(dprec.Vec3Sum(TilePosition(coord, 0), TilePosition(neighbor, 0)), 2.0)
+	position.Y = float64(b.Tile(coord).EdgeElevation(direction)) * TileElevationHeight
+	return dprec.Vec3Diff(position, TilePosition(center, int(b.Tile(center).Elevation)))
+}
+
+// StartRotation returns the rotation in the world that turns a vehicle that
+// faces the Z axis into the direction in which the route leaves the start
+// tile, so that it follows the start tile when the board is rotated.
+func (b *Board) StartRotation() dprec.Quat {
+	center := b.Center()
+	direction := dprec.Vec3Diff(
+		TilePosition(center.Neighbor(b.Tile(center).startExit()), 0),
+		TilePosition(center, 0),
+	)
+	return dprec.RotationQuat(dprec.Radians(math.Atan2(direction.X, direction.Z)), dprec.BasisYVec3())
+}
@@ -0,0 +1,90 @@
+package level_test
+
+import (
+	"testing"
+
+	"github.com/mokiat/gomath/dprec"
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+func TestBoardStartRotationFollowsRotate(t *testing.T) {
+	// The start tile lies on a loop that is passed through splits, so a
+	// route that leaves the start tile the other way takes other branches.
+	original, err := level.ParseBoardText(`
+		layout square 7
+		biome meadow
+		K2  C0  C1  C0  .0  C1  C0
+		  S2  K4  K2  K5  K3  K0  C5
+		K2  K5  C1  K5  .3  K3  Y2
+		  Y1  C4  C1  S0  K0  K1  C5
+		K3  C0  C2  C1  Y0  C2  K4
+		  .1  S5  K4  K2  K5  Y3  K0
+		.2  .4  C3  S3  K5  K3  C4
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalRoute, err := original.Route(level.BranchRuleStraight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for steps := range 6 {
+		board, err := original.Rotate(steps)
+		if err != nil {
+			t.Fatalf("rotation by %d steps: %v", steps, err)
+		}
+		route, err := board.Route(level.BranchRuleStraight)
+		if err != nil {
+			t.Fatalf("rotation by %d steps: %v", steps, err)
+		}
+		if len(route) != len(originalRoute) {
+			t.Fatalf("rotation by %d steps: expected %d steps, got %d", steps, len(originalRoute), len(route))
+		}
+		for i, step := range route {
+			if expected := (originalRoute[i].Exit + byte(steps)) % 6; step.Exit != expected {
+				t.Errorf("rotation by %d steps: step %d exits in direction %d instead of %d", steps, i, step.Exit, expected)
+			}
+		}
+
+		center := board.Center()
+		exit := (originalRoute[0].Exit + byte(steps)) % 6
+		expected := dprec.UnitVec3(dprec.Vec3Diff(
+			level.TilePosition(center.Neighbor(exit), 0),
+			level.TilePosition(center, 0),
+		))
+		forward := dprec.QuatVec3Rotation(board.StartRotation(), dprec.BasisZVec3())
+		if dprec.Vec3Dot(forward, expected) < 0.999 {
+			t.Errorf("rotation by %d steps: vehicle faces %v instead of %v", steps, forward, expected)
+		}
+	}
+}
+
+func TestBoardWorldRotationTiltsSlopes(t *testing.T) {
+	for rotation := range byte(6) {
+		board := ovalBoard(t)
+		coord := level.C(1, 0)
+		board.SetTile(coord, level.Tile{
+			Shape:    level.ShapeKindRoadSlope,
+			Rotation: rotation,
+			Ground:   level.GroundKindGrass,
+			Road:     level.RoadKindDirt,
+		})
+		position := board.WorldPosition(coord)
+		orientation := board.WorldRotation(coord)
+		for direction := range byte(6) {
+			if !board.Tile(coord).HasRoad(direction) {
+				continue
+			}
+			// The model of the slope is a straight road that runs along the
+			// X axis, with the raised side in the positive direction.
+			side := level.TileSpacing / 2.0
+			if board.Tile(coord).EdgeElevation(direction) == 0 {
+				side = -side
+			}
+			edge := dprec.Vec3Sum(position, dprec.QuatVec3Rotation(orientation, dprec.NewVec3(side, 0.0, 0.0)))
+			if expected := board.EdgeWorldPosition(coord, direction); dprec.Vec3Diff(edge, expected).Length() > 0.1 {
+				t.Errorf("rotation %d, direction %d: model edge at %v instead of %v", rotation, direction, edge, expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package preview
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+// RenderImage draws the board into a new image.
+func RenderImage(board *level.Board, options Options) *image.RGBA {
+	width, height := boardSize(board, tileRadius(options))
+	img := image.NewRGBA(image.Rect(0, 0, int(math.Ceil(width)), int(math.Ceil(height))))
+	drawBoard(&rasterCanvas{img: img}, board, options)
+	return img
+}
+
+// WritePNG draws the board and writes it as a PNG image.
+func WritePNG(out io.Writer, board *level.Board, options Options) error {
+	if err := png.Encode(out, RenderImage(board, options)); err != nil {
+		return fmt.Errorf("error encoding png: %w", err)
+	}
+	return nil
+}
+
+// rasterCanvas draws into an image by testing each pixel in the bounds of
+// a shape, which is fast enough for the small shapes of a board.
+type rasterCanvas struct {
+	img *image.RGBA
+}
+
+func (c *rasterCanvas) polygon(points []point, fill color.RGBA) {
+	c.fill(bounds(points, 0), func(p point) float64 {
+		// The polygons are convex and their points are in clockwise order,
+		// as seen with the Y axis pointing down.
+		for i, from := range points {
+			to := points[(i+1)%len(points)]
+			if (to.X-from.X)*(p.Y-from.Y)-(to.Y-from.Y)*(p.X-from.X) < 0 {
+				return 0
+			}
+		}
+		return 1
+	}, fill)
+}
+
+func (c *rasterCanvas) polyline(points []point, width float64, stroke color.RGBA) {
+	halfWidth := width / 2.0
+	c.fill(bounds(points, halfWidth+1), func(p point) float64 {
+		distance := math.Inf(1)
+		for i := 1; i < len(points); i++ {
+			distance = min(distance, segmentDistance(p, points[i-1], points[i]))
+		}
+		return coverage(halfWidth - distance)
+	}, stroke)
+}
+
+func (c *rasterCanvas) circle(center point, radius float64, fill color.RGBA) {
+	c.fill(bounds([]point{center}, radius+1), func(p point) float64 {
+		return coverage(radius - math.Hypot(p.X-center.X, p.Y-center.Y))
+	}, fill)
+}
+
+// fill blends the color into each pixel in the rectangle according to the
+// coverage of its center.
+func (c *rasterCanvas) fill(rect image.Rectangle, cover func(point) float64, fill color.RGBA) {
+	rect = rect.Intersect(c.img.Bounds())
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			amount := cover(point{X: float64(x) + 0.5, Y: float64(y) + 0.5})
+			if amount <= 0 {
+				continue
+			}
+			amount = min(amount, 1)
+			pix := c.img.Pix[c.img.PixOffset(x, y):]
+			for i, value := range [4]uint8{fill.R, fill.G, fill.B, fill.A} {
+				pix[i] = uint8(float64(value)*amount + float64(pix[i])*(1-amount))
+			}
+		}
+	}
+}
+
+func bounds(points []point, padding float64) image.Rectangle {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range points {
+		minX, minY = min(minX, p.X), min(minY, p.Y)
+		maxX, maxY = max(maxX, p.X), max(maxY, p.Y)
+	}
+	return image.Rect(
+		int(math.Floor(minX-padding)), int(math.Floor(minY-padding)),
+		int(math.Ceil(maxX+padding)), int(math.Ceil(maxY+padding)),
+	)
+}
+
+// coverage turns the distance of a pixel center inside a shape border into
+// the fraction of the pixel that the shape covers.
+func coverage(distance float64) float64 {
+	return max(0, min(1, distance+0.5))
+}
+
+func segmentDistance(p, from, to point) float64 {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	t := 0.0
+	if lengthSqr := dx*dx + dy*dy; lengthSqr > 0 {
+		t = max(0, min(1, ((p.X-from.X)*dx+(p.Y-from.Y)*dy)/lengthSqr))
+	}
+	return math.Hypot(p.X-(from.X+t*dx), p.Y-(from.Y+t*dy))
+}
@@ -0,0 +1,178 @@
+// Package preview draws top-down images of boards without using the GPU,
+// so that generated boards can be reviewed outside of the game.
+package preview
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+const defaultTileRadius = 24.0
+
+// Options control how a board is drawn.
+type Options struct {
+	// TileRadius is the distance in pixels between the center of a tile and
+	// its corners. Zero means that a default radius is used.
+	TileRadius float64
+}
+
+var (
+	groundColors = map[level.GroundKind]color.RGBA{
+		level.GroundKindGrass:  {R: 0x5C, G: 0x94, B: 0x3C, A: 0xFF},
+		level.GroundKindSand:   {R: 0xD8, G: 0xC3, B: 0x8A, A: 0xFF},
+		level.GroundKindSnow:   {R: 0xE6, G: 0xEC, B: 0xF0, A: 0xFF},
+		level.GroundKindGravel: {R: 0x9A, G: 0x95, B: 0x8C, A: 0xFF},
+	}
+	roadColors = map[level.RoadKind]color.RGBA{
+		level.RoadKindDirt:    {R: 0xA8, G: 0x7E, B: 0x4E, A: 0xFF},
+		level.RoadKindAsphalt: {R: 0x4A, G: 0x4A, B: 0x4A, A: 0xFF},
+		level.RoadKindGravel:  {R: 0xB5, G: 0xAF, B: 0xA3, A: 0xFF},
+	}
+	fallbackColor = color.RGBA{R: 0xFF, G: 0x00, B: 0xFF, A: 0xFF}
+	outlineColor  = color.RGBA{R: 0x20, G: 0x20, B: 0x20, A: 0xFF}
+	markerColor   = color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+)
+
+type point struct {
+	X float64
+	Y float64
+}
+
+// canvas is implemented by the image formats that a board can be drawn to.
+type canvas interface {
+	polygon(points []point, fill color.RGBA)
+	polyline(points []point, width float64, stroke color.RGBA)
+	circle(center point, radius float64, fill color.RGBA)
+}
+
+// boardSize returns the size in pixels of the image of the board.
+func boardSize(board *level.Board, radius float64) (float64, float64) {
+	width := math.Sqrt(3) * radius * (float64(board.Width()) + 0.5)
+	height := radius * (1.5*float64(board.Height()) + 0.5)
+	return width, height
+}
+
+// tileCenter returns the pixel position of the center of the tile at the
+// specified coordinate, using the same layout as level.TilePosition.
+func tileCenter(coord level.Coord, radius float64) point {
+	position := level.TilePosition(coord, 0)
+	scale := radius / (level.TileSize / 2.0)
+	return point{
+		X: position.X*scale + math.Sqrt(3)*radius/2.0,
+		Y: position.Z*scale + radius,
+	}
+}
+
+// edgePoint returns the point at the specified distance from the center
+// towards the middle of the tile border in the specified direction.
+func edgePoint(center point, direction byte, distance float64) point {
+	angle := float64(direction) * math.Pi / 3.0
+	return point{
+		X: center.X + distance*math.Cos(angle),
+		Y: center.Y + distance*math.Sin(angle),
+	}
+}
+
+func drawBoard(c canvas, board *level.Board, options Options) {
+	radius := tileRadius(options)
+	inradius := radius * math.Sqrt(3) / 2.0
+	roadWidth := radius * 0.4
+
+	for _, coord := range board.Coords() {
+		tile := board.Tile(coord)
+		if tile.Shape == level.ShapeKindNone {
+			continue
+		}
+		center := tileCenter(coord, radius)
+		corners := make([]point, 6)
+		for i := range corners {
+			angle := math.Pi/6.0 + float64(i)*math.Pi/3.0
+			corners[i] = point{
+				X: center.X + radius*math.Cos(angle),
+				Y: center.Y + radius*math.Sin(angle),
+			}
+		}
+		c.polygon(corners, elevated(colorOf(groundColors, tile.Ground), int(tile.Elevation)))
+	}
+
+	for _, coord := range board.Coords() {
+		tile := board.Tile(coord)
+		center := tileCenter(coord, radius)
+		road := elevated(colorOf(roadColors, tile.Road), int(tile.Elevation))
+		for i, group := range tile.RoadGroups() {
+			var points []point
+			switch len(group) {
+			case 1:
+				points = []point{edgePoint(center, group[0], inradius), center}
+			case 2:
+				points = curve(edgePoint(center, group[0], inradius), center, edgePoint(center, group[1], inradius))
+			default:
+				for _, direction := range group {
+					c.polyline([]point{edgePoint(center, direction, inradius), center}, roadWidth, road)
+				}
+				continue
+			}
+			if i > 0 {
+				// Later groups pass over the earlier ones.
+				c.polyline(points, roadWidth*1.5, outlineColor)
+			}
+			c.polyline(points, roadWidth, road)
+		}
+		for direction := range byte(6) {
+			if tile.HasRoad(direction) && tile.EdgeElevation(direction) > int(tile.Elevation) {
+				// Mark the raised side of slopes with a step across the road.
+				middle := edgePoint(center, direction, inradius*0.6)
+				across := edgePoint(point{}, (direction+1)%6, roadWidth*0.6)
+				c.polyline([]point{
+					{X: middle.X - across.X, Y: middle.Y - across.Y},
+					{X: middle.X + across.X, Y: middle.Y + across.Y},
+				}, radius*0.08, outlineColor)
+			}
+		}
+	}
+
+	start := tileCenter(board.Center(), radius)
+	c.circle(start, radius*0.3, outlineColor)
+	c.circle(start, radius*0.22, markerColor)
+}
+
+func tileRadius(options Options) float64 {
+	if options.TileRadius > 0 {
+		return options.TileRadius
+	}
+	return defaultTileRadius
+}
+
+// curve returns points along a quadratic Bezier curve.
+func curve(from, control, to point) []point {
+	const segments = 8
+	result := make([]point, segments+1)
+	for i := range result {
+		t := float64(i) / segments
+		a, b, c := (1-t)*(1-t), 2*(1-t)*t, t*t
+		result[i] = point{
+			X: a*from.X + b*control.X + c*to.X,
+			Y: a*from.Y + b*control.Y + c*to.Y,
+		}
+	}
+	return result
+}
+
+func colorOf[K comparable](colors map[K]color.RGBA, kind K) color.RGBA {
+	if result, ok := colors[kind]; ok {
+		return result
+	}
+	return fallbackColor
+}
+
+// elevated brightens the color according to the elevation, so that hills
+// stand out.
+func elevated(c color.RGBA, elevation int) color.RGBA {
+	amount := min(1.0, 0.12*float64(elevation))
+	blend := func(value uint8) uint8 {
+		return uint8(float64(value) + (255.0-float64(value))*amount)
+	}
+	return color.RGBA{R: blend(c.R), G: blend(c.G), B: blend(c.B), A: c.A}
+}
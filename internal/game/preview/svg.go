@@ -0,0 +1,52 @@
+package preview
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/mokiat/rally-mka/internal/game/level"
+)
+
+// WriteSVG draws the board and writes it as an SVG image.
+func WriteSVG(out io.Writer, board *level.Board, options Options) error {
+	width, height := boardSize(board, tileRadius(options))
+	writer := bufio.NewWriter(out)
+	fmt.Fprintf(writer, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.2f %.2f">`+"\n", width, height, width, height)
+	drawBoard(&svgCanvas{out: writer}, board, options)
+	fmt.Fprintln(writer, "</svg>")
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("error writing svg: %w", err)
+	}
+	return nil
+}
+
+type svgCanvas struct {
+	out io.Writer
+}
+
+func (c *svgCanvas) polygon(points []point, fill color.RGBA) {
+	fmt.Fprintf(c.out, `<polygon points="%s" fill="%s"/>`+"\n", svgPoints(points), svgColor(fill))
+}
+
+func (c *svgCanvas) polyline(points []point, width float64, stroke color.RGBA) {
+	fmt.Fprintf(c.out, `<polyline points="%s" fill="none" stroke="%s" stroke-width="%.2f" stroke-linecap="round" stroke-linejoin="round"/>`+"\n", svgPoints(points), svgColor(stroke), width)
+}
+
+func (c *svgCanvas) circle(center point, radius float64, fill color.RGBA) {
+	fmt.Fprintf(c.out, `<circle cx="%.2f" cy="%.2f" r="%.2f" fill="%s"/>`+"\n", center.X, center.Y, radius, svgColor(fill))
+}
+
+func svgPoints(points []point) string {
+	parts := make([]string, len(points))
+	for i, p := range points {
+		parts[i] = fmt.Sprintf("%.2f,%.2f", p.X, p.Y)
+	}
+	return strings.Join(parts, " ")
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"math"
 	"runtime"
 	"time"
 
@@ -59,22 +58,6 @@ type PlayController struct {
 	vehicle           *preset.Car
 }
 
-func tilePosition(coord level.Coord) dprec.Vec3 {
-	const tileSize = 80.0
-	x, y := coord.X, coord.Y
-	xShift := tileSize * float64(math.Sqrt(3)) / 2.0
-	yShift := tileSize * 3.0 / 4.0
-	xOffset := float64(0.0)
-	if max(y, -y)%2 == 1 {
-		xOffset = xShift / 2.0
-	}
-	return dprec.Vec3{
-		X: float64(x)*xShift + xOffset,
-		Y: 0.0,
-		Z: float64(y) * yShift,
-	}
-}
-
 func (c *PlayController) Start(environment data.Lighting, controller data.Input, board *level.Board) {
 	physics.ImpulseDriftAdjustmentRatio = 0.06 // FIXME: Use default once multi-point collisions are fixed
 
@@ -86,25 +69,20 @@ func (c *PlayController) Start(environment data.Lighting, controller data.Input,
 		IsDynamic:  true,
 	})
 
-	centerPosition := tilePosition(board.Center())
-	for y := range board.Size() {
-		for x := range board.Size() {
-			tileCoord := level.C(x, y)
-			tile := board.Tile(tileCoord)
-			nodeName := tile.NodeName()
-			if nodeName == "" {
-				continue
-			}
-			tilePosition := tilePosition(tileCoord)
-			position := dprec.Vec3Diff(tilePosition, centerPosition)
-			c.scene.CreateModel(game.ModelInfo{
-				RootNode:   opt.V(nodeName),
-				Position:   opt.V(position),
-				Rotation:   opt.V(tile.RotationQuat()),
-				Definition: c.playData.Scene,
-				IsDynamic:  false,
-			})
+	for _, tileCoord := range board.Coords() {
+		tile := board.Tile(tileCoord)
+		nodeName := tile.NodeName()
+		if nodeName == "" {
+			continue
 		}
+		position := board.WorldPosition(tileCoord)
+		c.scene.CreateModel(game.ModelInfo{
+			RootNode:   opt.V(nodeName),
+			Position:   opt.V(position),
+			Rotation:   opt.V(board.WorldRotation(tileCoord)),
+			Definition: c.playData.Scene,
+			IsDynamic:  false,
+		})
 	}
 
 	c.preUpdateSubscription = c.scene.SubscribePreUpdate(c.onPreUpdate)
@@ -131,7 +109,7 @@ func (c *PlayController) Start(environment data.Lighting, controller data.Input,
 	c.vehicle = c.vehicleDefinition.ApplyToModel(c.scene, preset.CarApplyInfo{
 		Model:    carModel,
 		Position: dprec.NewVec3(0.0, 0.5, 0.0),
-		Rotation: dprec.RotationQuat(dprec.Degrees(90), dprec.BasisYVec3()),
+		Rotation: board.StartRotation(),
 	})
 
 	var vehicleNodeComponent *preset.NodeComponent
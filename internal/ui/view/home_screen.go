@@ -410,14 +410,32 @@ func (c *homeScreenComponent) withLevelModeContent() {
 			Layout: layout.Anchor(),
 		})
 
-		co.WithChild("level-"+level.Name, co.New(widget.Level, func() {
+		co.WithChild("centered-pane", co.New(std.Element, func() {
 			co.WithLayoutData(layout.Data{
 				HorizontalCenter: opt.V(0),
 				VerticalCenter:   opt.V(0),
 			})
-			co.WithData(widget.LevelData{
-				Board: level.Board,
+			co.WithData(std.ElementData{
+				Layout: layout.Vertical(layout.VerticalSettings{
+					ContentAlignment: layout.HorizontalAlignmentCenter,
+					ContentSpacing:   20,
+				}),
 			})
+
+			co.WithChild("level-"+level.Name, co.New(widget.Level, func() {
+				co.WithData(widget.LevelData{
+					Board: level.Board,
+				})
+			}))
+
+			co.WithChild("level-text", co.New(std.Label, func() {
+				co.WithData(std.LabelData{
+					Font:      co.OpenFont(c.Scope(), "ui:///roboto-bold.ttf"),
+					FontSize:  opt.V(float32(24.0)),
+					FontColor: opt.V(ui.White()),
+					Text:      c.levelDescription(level),
+				})
+			}))
 		}))
 	}))
 }
@@ -501,6 +519,17 @@ func (c *homeScreenComponent) controllerDescription(controller data.Input) strin
 	}
 }
 
+func (c *homeScreenComponent) levelDescription(level data.Level) string {
+	metrics := level.Board.Metrics()
+	lap := "No lap"
+	if metrics.LapLength > 0 {
+		lap = fmt.Sprintf("Lap: %.1f km", metrics.LapLength/1000.0)
+	}
+	return fmt.Sprintf("%s, Corners: %d smooth, %d sharp, Longest straight: %.0f m, Difficulty: %.1f",
+		lap, metrics.SmoothCorners, metrics.SharpCorners, metrics.LongestStraight, metrics.Difficulty,
+	)
+}
+
 func (c *homeScreenComponent) onKeyboardClicked() {
 	c.homeModel.SetInput(data.InputKeyboard)
 	c.Invalidate()
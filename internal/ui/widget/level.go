@@ -21,7 +21,7 @@ type LevelData struct {
 type levelComponent struct {
 	co.BaseComponent
 
-	images      []*ui.Image
+	images      map[level.ShapeKind]*ui.Image
 	board       *level.Board
 	elapsedTime time.Duration
 }
@@ -30,13 +30,12 @@ func (c *levelComponent) OnCreate() {
 	data := co.GetData[LevelData](c.Properties())
 	c.board = data.Board
 
-	c.images = []*ui.Image{
-		nil,
-		co.OpenImage(c.Scope(), "ui/images/tile-grass.png"),
-		co.OpenImage(c.Scope(), "ui/images/tile-road-straight.png"),
-		co.OpenImage(c.Scope(), "ui/images/tile-road-corner-smooth.png"),
-		co.OpenImage(c.Scope(), "ui/images/tile-road-corner-sharp.png"),
-		co.OpenImage(c.Scope(), "ui/images/tile-road-split.png"),
+	catalog := level.DefaultTileCatalog()
+	c.images = make(map[level.ShapeKind]*ui.Image)
+	for _, shape := range catalog.Shapes() {
+		if thumbnail, ok := catalog.Thumbnail(shape); ok {
+			c.images[shape] = co.OpenImage(c.Scope(), thumbnail)
+		}
 	}
 	c.elapsedTime = 0
 }
@@ -59,36 +58,45 @@ func (c *levelComponent) OnRender(element *ui.Element, canvas *ui.Canvas) {
 	canvas.Translate(drawBounds.Position)
 
 	board := c.board
-	centerPosition := tilePosition(board.Center())
+	coords := board.Coords()
+
+	minPosition := sprec.NewVec2(math.MaxFloat32, math.MaxFloat32)
+	maxPosition := sprec.NewVec2(-math.MaxFloat32, -math.MaxFloat32)
+	for _, coord := range coords {
+		position := tilePosition(coord)
+		minPosition = sprec.NewVec2(min(minPosition.X, position.X), min(minPosition.Y, position.Y))
+		maxPosition = sprec.NewVec2(max(maxPosition.X, position.X), max(maxPosition.Y, position.Y))
+	}
+	boardCenter := sprec.Vec2Quot(sprec.Vec2Sum(minPosition, maxPosition), 2.0)
+	boardSize := sprec.Vec2Sum(sprec.Vec2Diff(maxPosition, minPosition), sprec.NewVec2(64.0, 64.0))
+	scale := min(1.0, drawBounds.Width()/boardSize.X, drawBounds.Height()/boardSize.Y)
+
+	canvas.Translate(sprec.Vec2Quot(drawBounds.Size, 2.0))
+	canvas.Scale(sprec.NewVec2(scale, scale))
+
 	appearAfter := c.elapsedTime
 	appearDuration := 10 * time.Millisecond
-	for y := range board.Size() {
-		for x := range board.Size() {
-			if appearAfter > appearDuration {
-				tileCoord := level.C(x, y)
-				tile := board.Tile(tileCoord)
-				image := c.images[tile.Shape]
-				tilePosition := sprec.Vec2Sum(
-					sprec.Vec2Diff(tilePosition(tileCoord), centerPosition),
-					sprec.NewVec2(290.0, 250.0),
-				)
-				canvas.Push()
-				canvas.Translate(tilePosition)
-				canvas.Rotate(-sprec.Degrees(60.0 * float32(tile.Rotation)))
-				canvas.Translate(sprec.NewVec2(-32.0, -32.0))
-				canvas.Reset()
-				canvas.Rectangle(sprec.ZeroVec2(), sprec.NewVec2(64.0, 64.0))
-				canvas.Fill(ui.Fill{
-					Rule:        ui.FillRuleSimple,
-					Color:       ui.White(),
-					Image:       image,
-					ImageOffset: sprec.ZeroVec2(),
-					ImageSize:   sprec.NewVec2(64.0, 64.0),
-				})
-				canvas.Pop()
-			}
-			appearAfter -= appearDuration
+	for _, tileCoord := range coords {
+		tile := board.Tile(tileCoord)
+		image := c.images[tile.Shape]
+		if appearAfter > appearDuration && image != nil {
+			tilePosition := sprec.Vec2Diff(tilePosition(tileCoord), boardCenter)
+			canvas.Push()
+			canvas.Translate(tilePosition)
+			canvas.Rotate(-sprec.Degrees(60.0 * float32(tile.Rotation)))
+			canvas.Translate(sprec.NewVec2(-32.0, -32.0))
+			canvas.Reset()
+			canvas.Rectangle(sprec.ZeroVec2(), sprec.NewVec2(64.0, 64.0))
+			canvas.Fill(ui.Fill{
+				Rule:        ui.FillRuleSimple,
+				Color:       ui.White(),
+				Image:       image,
+				ImageOffset: sprec.ZeroVec2(),
+				ImageSize:   sprec.NewVec2(64.0, 64.0),
+			})
+			canvas.Pop()
 		}
+		appearAfter -= appearDuration
 	}
 
 	if appearAfter < appearDuration {